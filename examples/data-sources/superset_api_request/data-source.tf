@@ -0,0 +1,7 @@
+data "superset_api_request" "tags" {
+  path = "/api/v1/tag/?q=${urlencode(provider::superset::rison({ page_size = 100 }))}"
+}
+
+output "tag_names" {
+  value = jsondecode(data.superset_api_request.tags.response_body).result[*].name
+}
@@ -0,0 +1,11 @@
+data "superset_chart_data" "smoke" {
+  chart_id  = 42
+  row_limit = 5
+}
+
+check "chart_returns_rows" {
+  assert {
+    condition     = data.superset_chart_data.smoke.row_count > 0
+    error_message = "Chart 42 returned no rows."
+  }
+}
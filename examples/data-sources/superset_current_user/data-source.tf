@@ -0,0 +1,8 @@
+data "superset_current_user" "me" {
+  lifecycle {
+    postcondition {
+      condition     = contains(self.roles, "Admin")
+      error_message = "The provider account needs the Admin role to manage roles."
+    }
+  }
+}
@@ -0,0 +1,15 @@
+data "superset_dashboard_permalink" "sales_germany" {
+  dashboard = "sales"
+
+  data_mask = jsonencode({
+    "NATIVE_FILTER-country" = {
+      filterState = { value = ["DE"] }
+    }
+  })
+
+  active_tabs = ["TAB-overview"]
+}
+
+output "sales_germany_url" {
+  value = data.superset_dashboard_permalink.sales_germany.url
+}
@@ -0,0 +1,11 @@
+data "superset_dataset_charts" "payments" {
+  dataset_id = 7
+}
+
+# Fail the plan while charts are still built on the dataset.
+check "payments_dataset_unused" {
+  assert {
+    condition     = length(data.superset_dataset_charts.payments.charts) == 0
+    error_message = "Dataset 7 still backs charts: ${join(", ", data.superset_dataset_charts.payments.charts[*].name)}"
+  }
+}
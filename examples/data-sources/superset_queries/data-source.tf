@@ -0,0 +1,10 @@
+data "superset_queries" "failed_last_week" {
+  database_id   = 2
+  status        = "failed"
+  started_after = timeadd(plantimestamp(), "-168h")
+  limit         = 500
+}
+
+output "failed_queries_per_user" {
+  value = { for q in data.superset_queries.failed_last_week.queries : q.user_name => q.id... }
+}
@@ -0,0 +1,8 @@
+# Export roles in the format of `superset fab export-roles`
+data "superset_role_export" "legacy" {
+  role_names = ["DWH-DB-Connect", "Analysts"]
+}
+
+output "legacy_roles" {
+  value = data.superset_role_export.legacy.json
+}
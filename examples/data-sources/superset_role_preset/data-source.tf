@@ -0,0 +1,17 @@
+data "superset_role_preset" "gamma" {
+  preset = "Gamma"
+}
+
+# Gamma plus SQL Lab access, without the ability to export charts.
+resource "superset_role_permissions" "analyst" {
+  role_name = "Analyst"
+  resource_permissions = setunion(
+    setsubtract(data.superset_role_preset.gamma.permissions, [
+      { permission = "can_export", view_menu = "Chart" },
+    ]),
+    [
+      { permission = "menu_access", view_menu = "SQL Lab" },
+      { permission = "can_execute_sql_query", view_menu = "SQLLab" },
+    ],
+  )
+}
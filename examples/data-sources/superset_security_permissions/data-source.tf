@@ -0,0 +1,12 @@
+data "superset_security_permissions" "all" {}
+
+# Read-only access to dashboards and charts, plus the SQL Lab menu entry.
+resource "superset_role_permissions" "viewer" {
+  role_name = "Viewer"
+  resource_permissions = concat(
+    [for p in data.superset_security_permissions.all.actions : { permission = p.permission, view_menu = p.view_menu }
+    if p.permission == "can_read" && contains(["Dashboard", "Chart"], p.view_menu)],
+    [for p in data.superset_security_permissions.all.menu_access : { permission = p.permission, view_menu = p.view_menu }
+    if p.view_menu == "SQL Lab"],
+  )
+}
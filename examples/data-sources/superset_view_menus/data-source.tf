@@ -0,0 +1 @@
+data "superset_view_menus" "all" {}
@@ -0,0 +1,11 @@
+# Provider functions require Terraform 1.8 or later.
+# Fail the plan when the chart exported from staging drifted from the one in production.
+check "payments_chart_promoted" {
+  assert {
+    condition = (
+      provider::superset::normalize_export(file("${path.module}/exports/staging/charts/Payments_by_day.yaml"), "owners") ==
+      provider::superset::normalize_export(file("${path.module}/exports/production/charts/Payments_by_day.yaml"), "owners")
+    )
+    error_message = "The Payments by day chart differs between staging and production."
+  }
+}
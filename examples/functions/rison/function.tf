@@ -0,0 +1,19 @@
+# Provider functions require Terraform 1.8 or later.
+locals {
+  database_query = provider::superset::rison({
+    filters = [
+      { col = "database_name", opr = "eq", value = "examples" },
+    ]
+    page_size = 100
+  })
+}
+
+# (filters:!((col:database_name,opr:eq,value:examples)),page_size:100)
+output "database_query" {
+  value = local.database_query
+}
+
+# Rison leaves the value unencoded for URLs; urlencode it before building a link.
+output "database_list_url" {
+  value = "https://superset.example.com/api/v1/database/?q=${urlencode(local.database_query)}"
+}
@@ -0,0 +1,15 @@
+# Create a tag, which the provider does not model, and delete it on destroy.
+resource "superset_api_request" "finance_tag" {
+  path = "/api/v1/tag/"
+  request_body = jsonencode({
+    name        = "finance"
+    description = "Dashboards of the finance team"
+  })
+
+  read_path   = "/api/v1/tag/{id}"
+  delete_path = "/api/v1/tag/{id}"
+}
+
+output "finance_tag_id" {
+  value = superset_api_request.finance_tag.object_id
+}
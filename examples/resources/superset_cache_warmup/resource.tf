@@ -0,0 +1,9 @@
+resource "superset_cache_warmup" "exec_dashboards" {
+  dashboard_ids = [12, 15]
+  chart_ids     = [101]
+
+  # Warm up again on every deployment of the dashboards.
+  triggers = {
+    deployment = var.deployment_id
+  }
+}
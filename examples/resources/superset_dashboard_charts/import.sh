@@ -0,0 +1,3 @@
+# Dashboard charts can be imported by specifying the numeric identifier of the dashboard,
+# which adopts every chart currently on it
+terraform import superset_dashboard_charts.example 7
@@ -0,0 +1,10 @@
+# Each module contributes its own charts to the shared dashboard
+resource "superset_dashboard_charts" "sales" {
+  dashboard_id = 7
+  chart_ids    = [11, 12]
+}
+
+resource "superset_dashboard_charts" "marketing" {
+  dashboard_id = 7
+  chart_ids    = [21]
+}
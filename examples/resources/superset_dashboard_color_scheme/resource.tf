@@ -0,0 +1,9 @@
+resource "superset_dashboard_color_scheme" "example" {
+  dashboard_id = 7
+  color_scheme = "supersetColors"
+
+  label_colors = {
+    Revenue = "#1FA8C9"
+    Costs   = "#454E7C"
+  }
+}
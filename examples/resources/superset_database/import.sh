@@ -1,2 +1,11 @@
 # Database can be imported by specifying the numeric identifier of the Database id
-terraform import superset_database.example 337
\ No newline at end of file
+terraform import superset_database.example 337
+
+# or by its UUID, as referenced in Superset export bundles
+terraform import superset_database.example f5007595-5a43-45d8-a1da-9612bdb12b22
+
+# or by its name, unless several connections share it
+terraform import superset_database.example DWH_database_connection4
+
+# Superset never returns the password: keep db_pass, db_pass_env or db_pass_ref in the
+# configuration, and the first apply after the import sends it to Superset
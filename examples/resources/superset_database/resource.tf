@@ -11,4 +11,30 @@ resource "superset_database" "example" {
   allow_dml        = false
   allow_run_async  = true
   expose_in_sqllab = false
-}
\ No newline at end of file
+
+  extra = jsonencode({
+    engine_params = {
+      connect_args = {
+        sslmode = "require"
+      }
+    }
+  })
+}
+# The password is read from the DWH_PG_PASSWORD environment variable of the
+# process running Terraform and is never written to the state.
+resource "superset_database" "from_env" {
+  connection_name  = "DWHConnection"
+  db_engine        = "postgresql"
+  db_user          = "dwhuser"
+  db_pass_env      = "DWH_PG_PASSWORD"
+  db_host          = "dwh.db.domain.com"
+  db_port          = 5432
+  db_name          = "dwh"
+  allow_ctas       = false
+  allow_cvas       = false
+  allow_dml        = false
+  allow_run_async  = true
+  expose_in_sqllab = true
+
+  disable_data_preview = true
+}
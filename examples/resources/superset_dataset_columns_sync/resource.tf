@@ -0,0 +1,7 @@
+resource "superset_dataset_columns_sync" "example" {
+  dataset_id = 42
+
+  triggers = {
+    migration = "2024-06-warehouse-rename"
+  }
+}
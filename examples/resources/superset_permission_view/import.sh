@@ -0,0 +1,2 @@
+# Permission views can be imported by specifying the permission and view menu names, separated by a comma
+terraform import superset_permission_view.example can_read,MapPlugin
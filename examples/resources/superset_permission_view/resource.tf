@@ -0,0 +1,16 @@
+# Register a plugin permission before granting it, as Superset only creates
+# it the first time the plugin is accessed
+resource "superset_permission_view" "map_plugin" {
+  permission = "can_read"
+  view_menu  = "MapPlugin"
+}
+
+resource "superset_role_permissions" "analysts" {
+  role_name = "Analysts"
+  resource_permissions = [
+    {
+      permission = superset_permission_view.map_plugin.permission
+      view_menu  = superset_permission_view.map_plugin.view_menu
+    },
+  ]
+}
@@ -0,0 +1,2 @@
+# Roles can be imported by specifying their names, separated by commas
+terraform import superset_role_import.legacy DWH-DB-Connect,Analysts
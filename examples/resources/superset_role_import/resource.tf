@@ -0,0 +1,23 @@
+# Push the roles previously loaded with `superset fab import-roles`
+resource "superset_role_import" "legacy" {
+  json = file("${path.module}/roles.json")
+}
+
+# Or describe them inline
+resource "superset_role_import" "analysts" {
+  json = jsonencode([
+    {
+      name = "Analysts"
+      permissions = [
+        {
+          permission = { name = "can_read" }
+          view_menu  = { name = "Dashboard" }
+        },
+        {
+          permission = { name = "can_read" }
+          view_menu  = { name = "Chart" }
+        },
+      ]
+    },
+  ])
+}
@@ -0,0 +1,2 @@
+# Role users can be imported by specifying the numeric identifier of the role
+terraform import superset_role_users.example 12
@@ -0,0 +1,10 @@
+resource "superset_role_users" "example" {
+  role_name = "Finance-Editors"
+  usernames = ["alice", "bob"]
+}
+
+# The members can also come from a group file kept next to the configuration
+resource "superset_role_users" "from_group_file" {
+  role_name = "Finance-Viewers"
+  usernames = yamldecode(file("${path.module}/groups/finance-viewers.yaml")).members
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Feature is a Superset setting that some API endpoints are only registered with.
+type Feature string
+
+// FeatureSecurityAPI registers the /api/v1/security/ endpoints for roles, permissions and users.
+const FeatureSecurityAPI Feature = "FAB_ADD_SECURITY_API"
+
+// featureEndpoints maps the API paths depending on a feature to that feature. Paths listed with
+// an empty feature are always registered, even though they share the prefix of a dependent path.
+var featureEndpoints = []struct {
+	prefix  string
+	feature Feature
+}{
+	{"/api/v1/security/login", ""},
+	{"/api/v1/security/refresh", ""},
+	{"/api/v1/security/csrf_token/", ""},
+	{"/api/v1/security/", FeatureSecurityAPI},
+}
+
+// featureProbes lists, for each feature, an endpoint Superset answers with 404 when the feature is off.
+var featureProbes = map[Feature]string{
+	FeatureSecurityAPI: "/api/v1/security/roles/?q=(page_size:1)",
+}
+
+// FeatureDisabledError is returned instead of a 404 Not Found when the endpoint is missing because
+// the Superset feature it depends on is turned off.
+type FeatureDisabledError struct {
+	Feature Feature
+}
+
+// Error names the setting to turn on.
+func (e *FeatureDisabledError) Error() string {
+	return fmt.Sprintf("the Superset API needed here is disabled, set %s = True in superset_config.py", e.Feature)
+}
+
+// featureCache holds the outcome of the feature probes. It is shared by all copies of the client
+// returned by WithContext, so each feature is probed at most once per provider configuration.
+type featureCache struct {
+	mu      sync.Mutex
+	enabled map[Feature]bool
+}
+
+// requiredFeature returns the feature the API path depends on, if any.
+func requiredFeature(path string) (Feature, bool) {
+	if i := strings.Index(path, "/api/v1/"); i >= 0 {
+		path = path[i:]
+	}
+	for _, endpoint := range featureEndpoints {
+		if strings.HasPrefix(path, endpoint.prefix) {
+			return endpoint.feature, endpoint.feature != ""
+		}
+	}
+	return "", false
+}
+
+// FeatureEnabled reports whether the feature is turned on, probing Superset on first use.
+func (c *Client) FeatureEnabled(feature Feature) (bool, error) {
+	if c.features != nil {
+		c.features.mu.Lock()
+		enabled, known := c.features.enabled[feature]
+		c.features.mu.Unlock()
+		if known {
+			return enabled, nil
+		}
+	}
+
+	endpoint, ok := featureProbes[feature]
+	if !ok {
+		return false, fmt.Errorf("no probe for Superset feature %s", feature)
+	}
+	req, err := http.NewRequestWithContext(c.context(), "GET", c.Host+endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	var enabled bool
+	switch resp.StatusCode {
+	case http.StatusOK:
+		enabled = true
+	case http.StatusNotFound:
+		enabled = false
+	default:
+		return false, fmt.Errorf("failed to probe Superset feature %s, status code: %d", feature, resp.StatusCode)
+	}
+
+	if c.features != nil {
+		c.features.mu.Lock()
+		if c.features.enabled == nil {
+			c.features.enabled = map[Feature]bool{}
+		}
+		c.features.enabled[feature] = enabled
+		c.features.mu.Unlock()
+	}
+	return enabled, nil
+}
+
+// featureDisabled returns a FeatureDisabledError when the 404 response to req comes from a turned
+// off feature rather than from a missing object. The response is closed in that case.
+func (c *Client) featureDisabled(req *http.Request, resp *http.Response) error {
+	feature, ok := requiredFeature(req.URL.Path)
+	if !ok {
+		return nil
+	}
+	if enabled, err := c.FeatureEnabled(feature); err != nil || enabled {
+		return nil
+	}
+	resp.Body.Close()
+	return &FeatureDisabledError{Feature: feature}
+}
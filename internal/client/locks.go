@@ -0,0 +1,38 @@
+package client
+
+import "sync"
+
+// keyedMutex serialises work on the same object while letting work on other objects run in parallel.
+// It is shared by all copies of the client returned by WithContext.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[int64]*sync.Mutex
+}
+
+// lock locks the mutex of the key and returns the function unlocking it.
+func (m *keyedMutex) lock(key int64) func() {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = map[int64]*sync.Mutex{}
+	}
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// LockChart serialises the read-modify-write updates of the chart with the given ID made through this
+// client and its copies, e.g. by several superset_dashboard_charts resources adding the same chart to
+// different dashboards in parallel. It returns the function releasing the lock. Updates made by other
+// Terraform runs or in the Superset UI are not serialised.
+func (c *Client) LockChart(chartID int64) func() {
+	if c.chartLocks == nil {
+		return func() {}
+	}
+	return c.chartLocks.lock(chartID)
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockChart(t *testing.T) {
+	c := &Client{chartLocks: &keyedMutex{}}
+
+	unlock := c.LockChart(7)
+
+	// Another chart is not blocked by the lock of chart 7
+	done := make(chan struct{})
+	go func() {
+		c.LockChart(8)()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected chart 8 to be locked while chart 7 is")
+	}
+
+	// The same chart waits until the lock is released
+	locked := make(chan struct{})
+	go func() {
+		c.LockChart(7)()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatal("expected chart 7 to wait for its lock")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("expected chart 7 to be locked once released")
+	}
+
+	// A client without locks, e.g. built in a test, does not block
+	(&Client{}).LockChart(7)()
+}
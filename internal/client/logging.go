@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Logging subsystems of the client, registered on the context by WithContext.
+const (
+	// LogSubsystemHTTP logs every request sent to Superset with its status, duration and X-Request-Id and Server-Timing response headers.
+	LogSubsystemHTTP = "client.http"
+	// LogSubsystemCache logs the hits and misses of the response caches.
+	LogSubsystemCache = "client.cache"
+)
+
+// troubleshootingHeaders are the response headers logged with each request, so a request can be
+// correlated with the logs of Superset and of the gateway in front of it.
+var troubleshootingHeaders = map[string]string{
+	"X-Request-Id":  "request_id",
+	"Server-Timing": "server_timing",
+}
+
+// NewLogSubsystem returns a copy of ctx with the named logging subsystem registered at the level set
+// for it in LogLevels. Subsystems without a level log at the level of the provider.
+func (c *Client) NewLogSubsystem(ctx context.Context, subsystem string) context.Context {
+	return tflog.NewSubsystem(ctx, subsystem, tflog.WithLevel(hclog.LevelFromString(c.LogLevels[subsystem])))
+}
+
+// logRequest logs a request sent to Superset with its outcome at trace level, as every request is
+// logged. Query strings are left out, as they can be long rison filters that would bury the request line.
+func logRequest(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"duration_ms": elapsed.Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemTrace(req.Context(), LogSubsystemHTTP, "Superset request failed", fields)
+		return
+	}
+	fields["status_code"] = resp.StatusCode
+	for header, field := range troubleshootingHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			fields[field] = value
+		}
+	}
+	tflog.SubsystemTrace(req.Context(), LogSubsystemHTTP, "Superset request sent", fields)
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrPollTimeout is wrapped by the error of an asynchronous operation that did not complete within AsyncPollTimeout.
+var ErrPollTimeout = errors.New("timed out waiting for Superset")
+
+const (
+	// defaultAsyncPollInterval is the delay between two checks of an asynchronous operation.
+	defaultAsyncPollInterval = time.Second
+	// defaultAsyncPollTimeout is how long an asynchronous operation is waited for before giving up.
+	defaultAsyncPollTimeout = 5 * time.Minute
+)
+
+// poll calls check every AsyncPollInterval until it reports the operation as done or fails. It gives up
+// with ErrPollTimeout after AsyncPollTimeout, and stops as soon as the client's context is cancelled,
+// so an interrupted apply or a resource timeout never leaves it waiting.
+func (c *Client) poll(operation string, check func() (bool, error)) error {
+	ctx := c.context()
+	deadline := time.NewTimer(c.AsyncPollTimeout)
+	defer deadline.Stop()
+
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", operation, ctx.Err())
+		case <-deadline.C:
+			return fmt.Errorf("%s: %w after %s", operation, ErrPollTimeout, c.AsyncPollTimeout)
+		case <-time.After(c.AsyncPollInterval):
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// preflightAPIs lists the APIs the provider needs, with the class permission Superset checks for them
+// and the objects of the provider that use them.
+var preflightAPIs = []struct {
+	endpoint   string
+	permission string
+	usedBy     string
+}{
+	{"/api/v1/security/roles/", "Role", "superset_role, superset_role_permissions and superset_roles"},
+	{"/api/v1/security/permissions-resources/", "PermissionViewMenu", "superset_role_permissions and superset_security_permissions"},
+	{"/api/v1/database/", "Database", "superset_database and superset_databases"},
+}
+
+// Preflight checks that the user the client is authenticated as can use the APIs the provider needs,
+// and returns a description of every missing capability. Each API is probed with a single GET of its
+// _info endpoint, which lists the permissions the user holds on it. Write permissions are only
+// required when the client is not read-only.
+func (c *Client) Preflight() ([]string, error) {
+	var missing []string
+	for _, api := range preflightAPIs {
+		endpoint := api.endpoint + "_info?q=(keys:!(permissions))"
+		resp, err := c.DoRequestWithoutCache("GET", endpoint, nil)
+		var disabled *FeatureDisabledError
+		if errors.As(err, &disabled) {
+			missing = append(missing, fmt.Sprintf("%s is not available, set %s = True in superset_config.py (used by %s)", api.endpoint, disabled.Feature, api.usedBy))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error making GET request to %s: %v", endpoint, err)
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusUnauthorized, http.StatusForbidden:
+			missing = append(missing, fmt.Sprintf("%s is forbidden, grant can_read on %s to the user (used by %s)", api.endpoint, api.permission, api.usedBy))
+			continue
+		default:
+			return nil, responseError("check access to "+api.endpoint, resp, body)
+		}
+
+		var info struct {
+			Permissions []string `json:"permissions"`
+		}
+		if err := json.Unmarshal(body, &info); err != nil {
+			return nil, fmt.Errorf("error unmarshalling response to struct: %v", err)
+		}
+
+		var lacking []string
+		if !slices.Contains(info.Permissions, "can_read") {
+			lacking = append(lacking, "can_read")
+		}
+		if !c.ReadOnly && !slices.Contains(info.Permissions, "can_write") {
+			lacking = append(lacking, "can_write")
+		}
+		if len(lacking) > 0 {
+			missing = append(missing, fmt.Sprintf("%s needs %s on %s (used by %s)", api.endpoint, strings.Join(lacking, " and "), api.permission, api.usedBy))
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// sensitiveHeaders lists the headers whose values are never written to a cassette.
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+	"X-Csrftoken":   true,
+}
+
+// cassetteNamePattern matches the characters replaced when an endpoint is turned into a file name.
+var cassetteNamePattern = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Cassette is a recorded request/response pair, as written by RecordHTTP and read by NewReplayTransport.
+type Cassette struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is the sanitized request of a cassette.
+type CassetteRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// CassetteResponse is the sanitized response of a cassette.
+type CassetteResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// recordingTransport writes every request/response pair going through it to a directory.
+type recordingTransport struct {
+	dir string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// RecordHTTP makes the client write a sanitized copy of every request/response pair it exchanges
+// with Superset to dir, one numbered JSON cassette per request. Credentials, tokens, cookies and
+// CSRF tokens are redacted, so cassettes can be attached to bug reports.
+func (c *Client) RecordHTTP(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create the HTTP recording directory: %w", err)
+	}
+	c.recorder = &recordingTransport{dir: dir}
+	return nil
+}
+
+// httpClient returns the HTTP client requests are sent with, recording them when RecordHTTP was called,
+// retrying them while Superset is unavailable and identifying them with UserAgent when set.
+func (c *Client) httpClient() *http.Client {
+	var transport http.RoundTripper
+	if c.recorder != nil {
+		transport = c.recorder
+	}
+	transport = &unavailableTransport{attempts: c.UnavailableRetryAttempts, delay: c.UnavailableRetryDelay, next: transport}
+	if c.UserAgent != "" {
+		transport = &userAgentTransport{userAgent: c.UserAgent, next: transport}
+	}
+	return &http.Client{Transport: transport}
+}
+
+// RoundTrip sends the request with the default transport and records the exchange.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if err := t.record(req, reqBody, resp, respBody); err != nil {
+		return nil, fmt.Errorf("failed to record HTTP exchange: %w", err)
+	}
+	return resp, nil
+}
+
+// record writes a sanitized cassette for one exchange.
+func (t *recordingTransport) record(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte) error {
+	// Cassettes hold the decoded body, so replayed responses are never compressed.
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(bytes.NewReader(respBody))
+		if err != nil {
+			return err
+		}
+		respBody, err = io.ReadAll(gz)
+		if err != nil {
+			return err
+		}
+	}
+
+	body := Scrub(string(respBody))
+	if strings.HasSuffix(req.URL.Path, "/security/csrf_token/") {
+		body = fmt.Sprintf(`{"result": "%s"}`, redacted)
+	}
+
+	cassette := Cassette{
+		Request: CassetteRequest{
+			Method:  req.Method,
+			URL:     Scrub(req.URL.String()),
+			Headers: sanitizeHeaders(req.Header),
+			Body:    Scrub(string(reqBody)),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    sanitizeHeaders(resp.Header),
+			Body:       body,
+		},
+	}
+	delete(cassette.Response.Headers, "Content-Encoding")
+	delete(cassette.Response.Headers, "Content-Length")
+
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seq++
+	name := fmt.Sprintf("%04d-%s-%s.json", t.seq, req.Method, strings.Trim(cassetteNamePattern.ReplaceAllString(req.URL.Path, "_"), "_"))
+	return os.WriteFile(filepath.Join(t.dir, name), data, 0o644)
+}
+
+// sanitizeHeaders flattens headers and redacts the sensitive ones.
+func sanitizeHeaders(header http.Header) map[string]string {
+	sanitized := map[string]string{}
+	for name, values := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			sanitized[name] = redacted
+			continue
+		}
+		sanitized[name] = Scrub(strings.Join(values, ", "))
+	}
+	return sanitized
+}
+
+// replayTransport answers requests from recorded cassettes.
+type replayTransport struct {
+	mu        sync.Mutex
+	cassettes map[string][]Cassette
+}
+
+// NewReplayTransport loads the cassettes written by RecordHTTP from dir and returns a transport
+// serving them. Requests are matched on method and URL; cassettes recorded for the same request
+// are served in recording order, the last one being repeated. Tests install it in place of
+// http.DefaultTransport, like httpmock does.
+func NewReplayTransport(dir string) (http.RoundTripper, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	transport := &replayTransport{cassettes: map[string][]Cassette{}}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var cassette Cassette
+		if err := json.Unmarshal(data, &cassette); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %w", file, err)
+		}
+		key := cassette.Request.Method + " " + cassette.Request.URL
+		transport.cassettes[key] = append(transport.cassettes[key], cassette)
+	}
+	return transport, nil
+}
+
+// RoundTrip returns the next recorded response for the request.
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + req.URL.String()
+
+	t.mu.Lock()
+	recorded := t.cassettes[key]
+	if len(recorded) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("no cassette recorded for %s", key)
+	}
+	cassette := recorded[0]
+	if len(recorded) > 1 {
+		t.cassettes[key] = recorded[1:]
+	}
+	t.mu.Unlock()
+
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", cassette.Response.StatusCode, http.StatusText(cassette.Response.StatusCode)),
+		StatusCode:    cassette.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(strings.NewReader(cassette.Response.Body)),
+		ContentLength: int64(len(cassette.Response.Body)),
+		Request:       req,
+	}
+	for name, value := range cassette.Response.Headers {
+		resp.Header.Set(name, value)
+	}
+	return resp, nil
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestRecordReplay(t *testing.T) {
+	c := newTestClient(t)
+	dir := t.TempDir()
+	if err := c.RecordHTTP(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte(`{"result": {"id": 1, "sqlalchemy_uri": "postgresql://superset:s3cr3t@db/analytics"}}`))
+	gz.Close()
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/1",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewBytesResponse(200, compressed.Bytes())
+			resp.Header.Set("Content-Encoding", "gzip")
+			resp.Header.Set("Set-Cookie", "session=abc")
+			resp.Header.Set("X-Request-Id", "4f1c2a9e")
+			return resp, nil
+		})
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+
+	resp, err := c.DoRequestWithoutCache("GET", "/api/v1/database/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if _, _, err := c.GetCSRFToken(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || filepath.Base(files[0]) != "0001-GET-api_v1_database_1.json" {
+		t.Fatalf("expected a cassette per request in order, got %v", files)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, secret := range []string{"s3cr3t", "fake-token", "session=abc", "fake-csrf-token"} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("expected %s to be redacted from %s, got %s", secret, file, data)
+			}
+		}
+	}
+
+	transport, err := NewReplayTransport(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		url        string
+		statusCode int
+		body       string
+		requestID  string
+		wantErr    bool
+	}{
+		"Database": {
+			url:        "http://superset-host/api/v1/database/1",
+			statusCode: 200,
+			body:       `{"result": {"id": 1, "sqlalchemy_uri": "postgresql://superset:XXXXXXXXXX@db/analytics"}}`,
+			requestID:  "4f1c2a9e",
+		},
+		"CSRFToken": {
+			url:        "http://superset-host/api/v1/security/csrf_token/",
+			statusCode: 200,
+			body:       `{"result": "XXXXXXXXXX"}`,
+		},
+		"NotRecorded": {
+			url:     "http://superset-host/api/v1/database/2",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tc.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := transport.RoundTrip(req)
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected an error for a request without cassette")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tc.statusCode || string(body) != tc.body || resp.Header.Get("X-Request-Id") != tc.requestID {
+				t.Errorf("expected %d %s, got %d %s", tc.statusCode, tc.body, resp.StatusCode, body)
+			}
+			if resp.Header.Get("Content-Encoding") != "" {
+				t.Error("expected the replayed response to be uncompressed")
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// risonNotIDChars are the characters that force a rison string to be quoted, and risonNotIDStart
+// the ones it may not start with unquoted.
+const (
+	risonNotIDChars = " '!:(),*@$"
+	risonNotIDStart = "-0123456789"
+)
+
+// Rison encodes a value as rison, the compact JSON-like notation of the q= parameter of Superset's
+// REST API. It accepts the types encoding/json decodes into (nil, bool, float64, string, []interface{}
+// and map[string]interface{}), as well as *big.Float and the int types. Object keys are sorted.
+func Rison(value interface{}) (string, error) {
+	var b strings.Builder
+	if err := writeRison(&b, value); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// writeRison appends the rison encoding of value to b.
+func writeRison(b *strings.Builder, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		b.WriteString("!n")
+	case bool:
+		if v {
+			b.WriteString("!t")
+		} else {
+			b.WriteString("!f")
+		}
+	case int:
+		b.WriteString(strconv.Itoa(v))
+	case int64:
+		b.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		b.WriteString(risonNumber(big.NewFloat(v)))
+	case *big.Float:
+		b.WriteString(risonNumber(v))
+	case string:
+		b.WriteString(risonIDOrString(v))
+	case []interface{}:
+		b.WriteString("!(")
+		for i, item := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := writeRison(b, item); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(')')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		b.WriteByte('(')
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(risonIDOrString(key))
+			b.WriteByte(':')
+			if err := writeRison(b, v[key]); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(')')
+	default:
+		return fmt.Errorf("cannot encode %T as rison", value)
+	}
+	return nil
+}
+
+// risonNumber formats a number the way rison expects it: integers that fit in an int64 in full, other
+// numbers in the shortest form, with an exponent but no "+" sign, which rison reserves.
+func risonNumber(n *big.Float) string {
+	if i, accuracy := n.Int64(); accuracy == big.Exact {
+		return strconv.FormatInt(i, 10)
+	}
+	f, _ := n.Float64()
+	return strings.Replace(strconv.FormatFloat(f, 'g', -1, 64), "e+", "e", 1)
+}
+
+// risonIDOrString leaves strings that are valid rison identifiers, e.g. database_name, unquoted
+// and quotes the others.
+func risonIDOrString(value string) string {
+	if value == "" || strings.ContainsAny(value[:1], risonNotIDStart) || strings.ContainsAny(value, risonNotIDChars) {
+		return risonString(value)
+	}
+	return value
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrNoSecretCommand is returned by ResolveSecret when the client has no secret command configured.
+var ErrNoSecretCommand = errors.New("no secret command configured")
+
+// ResolveSecret runs SecretCommand with ref appended as its last argument and returns what the
+// command printed on stdout, without the trailing newline. The reference is opaque to the client,
+// e.g. "vault:kv/data/superset#dwh", and is interpreted by the command alone.
+func (c *Client) ResolveSecret(ref string) (string, error) {
+	if len(c.SecretCommand) == 0 {
+		return "", ErrNoSecretCommand
+	}
+
+	args := append(append([]string{}, c.SecretCommand[1:]...), ref)
+	cmd := exec.CommandContext(c.context(), c.SecretCommand[0], args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// The command's stderr is reported, but never its stdout, which may hold part of the secret.
+		return "", fmt.Errorf("secret command failed for %q: %w: %s", ref, err, strings.TrimSpace(stderr.String()))
+	}
+
+	secret := strings.TrimRight(stdout.String(), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("secret command returned an empty value for %q", ref)
+	}
+	return secret, nil
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Causes of a failed login, wrapped by the error returned when Superset rejects the credentials.
+var (
+	// ErrInvalidCredentials means the username or password is wrong, or the user is inactive:
+	// Superset answers both with the same 401 Not authorized.
+	ErrInvalidCredentials = errors.New("invalid username or password, or inactive user")
+	// ErrLoginProviderDisabled means Superset does not accept the "db" login provider, e.g. when it
+	// authenticates users with OAuth or LDAP.
+	ErrLoginProviderDisabled = errors.New("database login provider not enabled")
+	// ErrLoginRateLimited means Superset refused the login because of too many recent attempts.
+	ErrLoginRateLimited = errors.New("too many login attempts")
+)
+
+// loginError builds the error of a rejected login from the status code and the FAB error body.
+func loginError(statusCode int, body []byte) error {
+	var result struct {
+		Message json.RawMessage `json:"message"`
+	}
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &result); err == nil && len(result.Message) > 0 {
+		message = string(result.Message)
+		var text string
+		if json.Unmarshal(result.Message, &text) == nil {
+			message = text
+		}
+	}
+
+	var cause error
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		cause = ErrInvalidCredentials
+	case statusCode == http.StatusTooManyRequests:
+		cause = ErrLoginRateLimited
+	case statusCode == http.StatusBadRequest && strings.Contains(strings.ToLower(message), "provider"):
+		cause = ErrLoginProviderDisabled
+	default:
+		return fmt.Errorf("failed to authenticate with Superset, status code: %d, response: %s", statusCode, Scrub(message))
+	}
+	return fmt.Errorf("failed to authenticate with Superset: %w (status code: %d, message: %s)", cause, statusCode, Scrub(message))
+}
+
+// logins deduplicates concurrent logins of the same user to the same Superset instance, e.g. by
+// several aliased provider configurations, so they share one session instead of racing for it.
+var logins singleflight.Group
+
+// loginResult is the outcome of a login, shared by all the clients that asked for it concurrently.
+type loginResult struct {
+	accessToken  string
+	refreshToken string
+	cookies      []*http.Cookie
+}
+
+// csrfResult is a CSRF token shared by all the requests that asked for one concurrently.
+type csrfResult struct {
+	token   string
+	cookies []*http.Cookie
+}
+
+// session holds the tokens of an authenticated client. It is shared by all copies of the
+// client returned by WithContext, so a token refreshed by one copy is used by every other.
+type session struct {
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+
+	// csrf deduplicates concurrent CSRF token fetches.
+	csrf singleflight.Group
+}
+
+// login exchanges the credentials for new tokens. Concurrent logins with the same credentials
+// share a single request.
+func (c *Client) login() (string, string, error) {
+	password := sha256.Sum256([]byte(c.Password))
+	key := c.Host + "\x00" + c.Username + "\x00" + hex.EncodeToString(password[:])
+
+	value, err, _ := logins.Do(key, func() (interface{}, error) {
+		return c.requestLogin()
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	result := value.(loginResult)
+	c.Cookies = result.cookies
+	return result.accessToken, result.refreshToken, nil
+}
+
+// tokens returns the current access and refresh tokens.
+func (s *session) tokens() (string, string) {
+	if s == nil {
+		return "", ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accessToken, s.refreshToken
+}
+
+// setTokens stores new tokens, keeping the refresh token when none is given.
+func (s *session) setTokens(accessToken, refreshToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessToken = accessToken
+	if refreshToken != "" {
+		s.refreshToken = refreshToken
+	}
+}
+
+// do sends an authenticated request. A 404 Not Found caused by a turned off Superset feature is
+// returned as a FeatureDisabledError, so it is not mistaken for a deleted object.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.send(req)
+	logRequest(req, resp, err, time.Since(start))
+	if err != nil || resp.StatusCode != http.StatusNotFound {
+		return resp, err
+	}
+	if err := c.featureDisabled(req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// send sends an authenticated request. When SessionKeepalive is set and Superset rejects the
+// access token, the session is renewed and the request is sent once more, so an apply that
+// outlives the token lifetime (e.g. behind a long database migration) does not fail.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	accessToken, _ := c.session.tokens()
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !c.SessionKeepalive {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	if err := c.renewSession(accessToken); err != nil {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	accessToken, _ = c.session.tokens()
+	retry.Header.Set("Authorization", "Bearer "+accessToken)
+	return c.httpClient().Do(retry)
+}
+
+// renewSession obtains a new access token, unless another request already replaced the rejected one.
+// The refresh token is used when Superset issued one; logging in again is the fallback.
+func (c *Client) renewSession(rejectedToken string) error {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+
+	if c.session.accessToken != rejectedToken {
+		return nil
+	}
+
+	if c.session.refreshToken != "" {
+		accessToken, err := c.refreshAccessToken(c.session.refreshToken)
+		if err == nil {
+			c.session.accessToken = accessToken
+			return nil
+		}
+	}
+
+	accessToken, refreshToken, err := c.login()
+	if err != nil {
+		return err
+	}
+	c.session.accessToken = accessToken
+	if refreshToken != "" {
+		c.session.refreshToken = refreshToken
+	}
+	return nil
+}
+
+// refreshAccessToken exchanges the refresh token for a new access token.
+func (c *Client) refreshAccessToken(refreshToken string) (string, error) {
+	url := fmt.Sprintf("%s/api/v1/security/refresh", c.Host)
+	req, err := http.NewRequestWithContext(c.context(), "POST", url, bytes.NewBuffer(nil))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+refreshToken)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to refresh the Superset session, status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return "", err
+	}
+	if result.AccessToken == "" {
+		return "", fmt.Errorf("failed to retrieve access token from refresh response")
+	}
+	return result.AccessToken, nil
+}
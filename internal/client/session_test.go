@@ -0,0 +1,74 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestSharedLoginAndCSRF(t *testing.T) {
+	cases := map[string]struct {
+		method string
+		url    string
+		body   string
+		call   func(c *Client) error
+	}{
+		"Login": {
+			method: "POST",
+			url:    "http://superset-host/api/v1/security/login",
+			body:   `{"access_token": "fake-token", "refresh_token": "fake-refresh-token"}`,
+			call: func(*Client) error {
+				_, err := NewClient("http://superset-host", "fake-username", "fake-password", "")
+				return err
+			},
+		},
+		"CSRFToken": {
+			method: "GET",
+			url:    "http://superset-host/api/v1/security/csrf_token/",
+			body:   `{"result": "fake-csrf-token"}`,
+			call: func(c *Client) error {
+				_, _, err := c.GetCSRFToken()
+				return err
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t)
+
+			// The response is served slowly, so every caller joins the request of the first
+			release := make(chan struct{})
+			httpmock.RegisterResponder(tc.method, tc.url, func(req *http.Request) (*http.Response, error) {
+				<-release
+				return httpmock.NewStringResponse(200, tc.body), nil
+			})
+			httpmock.ZeroCallCounters()
+
+			var wg sync.WaitGroup
+			errs := make([]error, 5)
+			for i := range errs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs[i] = tc.call(c)
+				}()
+			}
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			for _, err := range errs {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if calls := httpmock.GetCallCountInfo()[tc.method+" "+tc.url]; calls != 1 {
+				t.Errorf("expected the callers to share a single request, got %d", calls)
+			}
+		})
+	}
+}
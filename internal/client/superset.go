@@ -2,28 +2,169 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
 )
 
+// ErrNotFound is wrapped by the errors of lookups that got a 404 Not Found from Superset.
+var ErrNotFound = errors.New("not found")
+
+// ErrAmbiguousName is wrapped by the errors of lookups by name that match several objects, which
+// Superset allows for some of them, e.g. database connections.
+var ErrAmbiguousName = errors.New("ambiguous name")
+
+// ErrBuiltInRole is wrapped by the errors of changes refused because they target a built-in role.
+var ErrBuiltInRole = errors.New("built-in role")
+
+// BuiltInRoleNames lists the roles Superset creates and keeps in sync on every upgrade.
+var BuiltInRoleNames = []string{"Admin", "Alpha", "Gamma", "sql_lab", "Public"}
+
 // Client represents a client for Superset API.
 type Client struct {
 	Host     string
 	Username string
 	Password string
-	Token    string
 	Cookies  []*http.Cookie
+
+	// UserAgent is sent with every request, including the login, so gateways can tell provider traffic apart.
+	UserAgent string
+
+	// DisableCache turns off the ETag and decoded response caches, so every request hits Superset.
+	DisableCache bool
+
+	// SessionKeepalive renews the session and retries when Superset rejects an expired access token.
+	SessionKeepalive bool
+
+	// ReadOnly marks the client as used by a provider that must never change Superset.
+	ReadOnly bool
+
+	// AllowBuiltInRoleChanges lets UpdateRole, DeleteRole and ClearRolePermissions change the built-in
+	// roles, which they otherwise refuse: clearing the permissions of Admin locks everyone out of Superset.
+	AllowBuiltInRoleChanges bool
+
+	// ManagedRolePrefix is the prefix the names of the roles managed by the provider must start with.
+	// It is empty when any role may be managed.
+	ManagedRolePrefix string
+
+	// CreateReadRetryAttempts and CreateReadRetryDelay bound how long resources wait for a
+	// just-created object to become readable, as Superset may serve reads from a lagging replica.
+	CreateReadRetryAttempts int
+	CreateReadRetryDelay    time.Duration
+
+	// UnavailableRetryAttempts and UnavailableRetryDelay bound the retries of requests Superset answers
+	// with an HTML error page, e.g. during an upgrade. The delay doubles after each retry.
+	UnavailableRetryAttempts int
+	UnavailableRetryDelay    time.Duration
+
+	// AsyncPollInterval and AsyncPollTimeout pace and bound the wait for operations Superset runs
+	// asynchronously, e.g. chart data queries when global async queries are enabled.
+	AsyncPollInterval time.Duration
+	AsyncPollTimeout  time.Duration
+
+	// ManagedExternally and ExternalURL are set on the objects created and updated by the provider,
+	// so Superset locks them against edits in the UI.
+	ManagedExternally bool
+	ExternalURL       string
+
+	// SecretCommand is the command and arguments run by ResolveSecret to look up secret references.
+	SecretCommand []string
+
+	// LogLevels sets the level of logging subsystems by name, e.g. "client.http": "warn", see NewLogSubsystem.
+	LogLevels map[string]string
+
+	// ctx bounds every request sent by the client, see WithContext.
+	ctx context.Context
+
+	// session holds the tokens and is shared by all copies of the client returned by WithContext.
+	session *session
+
+	// cache is shared by all copies of the client returned by WithContext.
+	cache *responseCache
+
+	// features caches which Superset features are turned on, see FeatureEnabled.
+	features *featureCache
+
+	// chartLocks serialises the updates of each chart, see LockChart.
+	chartLocks *keyedMutex
+
+	// recorder records HTTP exchanges when set, see RecordHTTP.
+	recorder *recordingTransport
+}
+
+// cacheKey scopes a cached endpoint to the Superset instance and user it was fetched for.
+// Each provider configuration owns its cache already; keying on host and username keeps
+// entries of different instances or users apart even if a cache ends up being shared.
+func (c *Client) cacheKey(endpoint string) string {
+	return c.Username + "@" + c.Host + endpoint
+}
+
+// responseCache holds the ETag and decoded response caches of a client.
+type responseCache struct {
+	etagMu    sync.Mutex
+	etagCache map[string]etagEntry
+
+	decodedMu    sync.Mutex
+	decodedCache map[string]decodedEntry
+
+	// inflight deduplicates identical GET requests issued concurrently, e.g. by several data
+	// sources listing the same objects while Terraform refreshes them in parallel.
+	inflight singleflight.Group
+}
+
+// sharedResponse is the response of a GET request shared by all the callers that asked for it concurrently.
+type sharedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+// decodedEntry holds an already decoded response body together with the ETag it was decoded from.
+type decodedEntry struct {
+	etag  string
+	value interface{}
+}
+
+// etagEntry holds the last ETag seen for an endpoint together with the body it was sent with.
+type etagEntry struct {
+	etag string
+	body []byte
 }
 
 // NewClient creates a new Superset client with the specified host, username, and password.
+// Every request, starting with the login, carries userAgent unless it is empty.
 // It returns a pointer to the created Client and an error if authentication fails.
-func NewClient(host, username, password string) (*Client, error) {
+func NewClient(host, username, password, userAgent string) (*Client, error) {
 	client := &Client{
-		Host:     host,
-		Username: username,
-		Password: password,
+		Host:      host,
+		Username:  username,
+		Password:  password,
+		UserAgent: userAgent,
+
+		UnavailableRetryAttempts: defaultUnavailableRetryAttempts,
+		UnavailableRetryDelay:    defaultUnavailableRetryDelay,
+
+		AsyncPollInterval: defaultAsyncPollInterval,
+		AsyncPollTimeout:  defaultAsyncPollTimeout,
+
+		session:  &session{},
+		cache:    &responseCache{},
+		features: &featureCache{},
+
+		chartLocks: &keyedMutex{},
 	}
 
 	err := client.authenticate()
@@ -37,53 +178,81 @@ func NewClient(host, username, password string) (*Client, error) {
 // authenticate sends an authentication request to the Superset API using the provided username and password.
 // It returns an error if the authentication fails or if there is an error during the request.
 func (c *Client) authenticate() error {
+	accessToken, refreshToken, err := c.login()
+	if err != nil {
+		return err
+	}
+
+	c.session.setTokens(accessToken, refreshToken)
+	return nil
+}
+
+// requestLogin exchanges the username and password for an access token and, when Superset issues one, a refresh token.
+func (c *Client) requestLogin() (loginResult, error) {
 	url := fmt.Sprintf("%s/api/v1/security/login", c.Host)
-	payload := map[string]string{
+	payload := map[string]interface{}{
 		"username": c.Username,
 		"password": c.Password,
 		"provider": "db",
+		"refresh":  true,
 	}
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		return err
+		return loginResult{}, err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(c.context(), "POST", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
-		return err
+		return loginResult{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := c.httpClient()
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return loginResult{}, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to authenticate with Superset, status code: %d", resp.StatusCode)
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return loginResult{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return loginResult{}, loginError(resp.StatusCode, body)
 	}
 
 	var result map[string]interface{}
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return err
+		return loginResult{}, err
 	}
 
 	token, ok := result["access_token"].(string)
 	if !ok {
-		return fmt.Errorf("failed to retrieve access token from response")
+		return loginResult{}, fmt.Errorf("failed to retrieve access token from response")
 	}
+	refreshToken, _ := result["refresh_token"].(string)
 
-	c.Token = token
-	c.Cookies = resp.Cookies()
-	return nil
+	return loginResult{accessToken: token, refreshToken: refreshToken, cookies: resp.Cookies()}, nil
+}
+
+// WithContext returns a copy of the client whose requests are bound to ctx, so they are
+// aborted once the context is cancelled or its deadline expires, and logged through the
+// client's logging subsystems. The copy shares the credentials and response caches of the
+// original client.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	bound := *c
+	bound.ctx = c.NewLogSubsystem(c.NewLogSubsystem(ctx, LogSubsystemHTTP), LogSubsystemCache)
+	return &bound
+}
+
+// context returns the context requests are bound to, defaulting to the background context.
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
 }
 
 // DoRequest sends an HTTP request to the specified endpoint using the specified method.
@@ -91,6 +260,17 @@ func (c *Client) authenticate() error {
 // If a payload is provided, it will be serialized to JSON before sending the request.
 // The function returns the HTTP response and an error, if any.
 func (c *Client) DoRequest(method, endpoint string, payload interface{}) (*http.Response, error) {
+	return c.doRequest(method, endpoint, payload, !c.DisableCache)
+}
+
+// DoRequestWithoutCache behaves like DoRequest but never answers a GET from the client caches,
+// for reads that must observe the latest server state regardless of ETags.
+func (c *Client) DoRequestWithoutCache(method, endpoint string, payload interface{}) (*http.Response, error) {
+	return c.doRequest(method, endpoint, payload, false)
+}
+
+// doRequest sends the request, using conditional GETs only when useCache is set.
+func (c *Client) doRequest(method, endpoint string, payload interface{}, useCache bool) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", c.Host, endpoint)
 	var jsonPayload []byte
 	var err error
@@ -102,15 +282,260 @@ func (c *Client) DoRequest(method, endpoint string, payload interface{}) (*http.
 		}
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(c.context(), method, url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	if method != http.MethodGet || !useCache || c.cache == nil {
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, err
+		}
+		return decompressResponse(resp)
+	}
+
+	return c.sharedGet(req, endpoint)
+}
+
+// sharedRequestTimeout bounds a request shared by concurrent callers, which is detached from their
+// cancellation and would otherwise be left unbounded once every caller stopped waiting for it.
+const sharedRequestTimeout = 5 * time.Minute
+
+// share runs fetch once for all the callers asking for key at the same time. The fetch runs on a context
+// detached from the caller that started it, so the operation timeout of that caller expiring does not
+// fail the callers that joined it, and each caller stops waiting when its own context is done.
+func (c *Client) share(ctx context.Context, key string, fetch func(context.Context) (interface{}, error)) (interface{}, error) {
+	result := c.cache.inflight.DoChan(key, func() (interface{}, error) {
+		detached, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedRequestTimeout)
+		defer cancel()
+		return fetch(detached)
+	})
+
+	select {
+	case shared := <-result:
+		return shared.Val, shared.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// sharedGet sends the GET request once for all callers asking for the same endpoint at the same time.
+// Each caller gets its own copy of the response, with a body read from the shared buffer.
+func (c *Client) sharedGet(req *http.Request, endpoint string) (*http.Response, error) {
+	value, err := c.share(req.Context(), c.cacheKey(endpoint), func(ctx context.Context) (interface{}, error) {
+		resp, err := c.doConditionalGet(req.Clone(ctx), endpoint)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &sharedResponse{resp: resp, body: body}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shared := value.(*sharedResponse)
+	resp := *shared.resp
+	resp.Header = shared.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(shared.body))
+	resp.ContentLength = int64(len(shared.body))
+	return &resp, nil
+}
+
+// decompressResponse wraps the response body in a gzip reader when the server compressed it.
+// Setting Accept-Encoding ourselves disables the transparent decompression of net/http,
+// so every response sent with that header must go through this function.
+func decompressResponse(resp *http.Response) (*http.Response, error) {
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		return resp, nil
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	resp.Body = &gzipReadCloser{Reader: gz, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// gzipReadCloser closes both the gzip reader and the underlying response body.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+// Close closes the gzip reader and the underlying response body.
+func (g *gzipReadCloser) Close() error {
+	gzErr := g.Reader.Close()
+	if err := g.body.Close(); err != nil {
+		return err
+	}
+	return gzErr
+}
+
+// decodedResponse is the outcome of a decodedGet, shared by all the callers that asked for it concurrently.
+type decodedResponse struct {
+	value      interface{}
+	statusCode int
+}
+
+// decodedGet sends a GET request to an endpoint whose decoded body is cached, once for all the callers
+// asking for it at the same time, and returns the decoded body along with the status code. The body is
+// decoded straight from the response stream with decode, and only its ETag and decoded value are kept,
+// so an unchanged endpoint is revalidated with If-None-Match without holding a copy of its raw body.
+// The value is nil when the status code is not 200 OK.
+func (c *Client) decodedGet(endpoint string, decode func(io.Reader) (interface{}, error)) (interface{}, int, error) {
+	if c.DisableCache || c.cache == nil {
+		resp, err := c.DoRequestWithoutCache("GET", endpoint, nil)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, resp.StatusCode, nil
+		}
+		value, err := decode(resp.Body)
+		return value, resp.StatusCode, err
+	}
+
+	key := c.cacheKey(endpoint)
+	value, err := c.share(c.context(), "decoded "+key, func(ctx context.Context) (interface{}, error) {
+		c.cache.decodedMu.Lock()
+		cached, hasCached := c.cache.decodedCache[key]
+		c.cache.decodedMu.Unlock()
+
+		req, err := http.NewRequestWithContext(ctx, "GET", c.Host+endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept-Encoding", "gzip")
+		if hasCached {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotModified && hasCached {
+			resp.Body.Close()
+			tflog.SubsystemDebug(ctx, LogSubsystemCache, "Served from the decoded response cache", map[string]interface{}{"path": req.URL.Path})
+			return decodedResponse{value: cached.value, statusCode: http.StatusOK}, nil
+		}
+
+		tflog.SubsystemDebug(ctx, LogSubsystemCache, "Not served from the decoded response cache", map[string]interface{}{
+			"path":        req.URL.Path,
+			"had_etag":    hasCached,
+			"status_code": resp.StatusCode,
+		})
+
+		resp, err = decompressResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
 
-	client := &http.Client{}
-	return client.Do(req)
+		if resp.StatusCode != http.StatusOK {
+			return decodedResponse{statusCode: resp.StatusCode}, nil
+		}
+		value, err := decode(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			c.cache.decodedMu.Lock()
+			if c.cache.decodedCache == nil {
+				c.cache.decodedCache = map[string]decodedEntry{}
+			}
+			c.cache.decodedCache[key] = decodedEntry{etag: etag, value: value}
+			c.cache.decodedMu.Unlock()
+		}
+		return decodedResponse{value: value, statusCode: http.StatusOK}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	decoded := value.(decodedResponse)
+	return decoded.value, decoded.statusCode, nil
+}
+
+// doConditionalGet sends a GET request with If-None-Match when an ETag is known for the endpoint.
+// A 304 Not Modified response is turned into a 200 OK carrying the cached body, so callers
+// don't have to care whether the response was served from the cache.
+func (c *Client) doConditionalGet(req *http.Request, endpoint string) (*http.Response, error) {
+	c.cache.etagMu.Lock()
+	cached, hasCached := c.cache.etagCache[c.cacheKey(endpoint)]
+	c.cache.etagMu.Unlock()
+
+	if hasCached {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		tflog.SubsystemDebug(req.Context(), LogSubsystemCache, "Served from the ETag cache", map[string]interface{}{"path": req.URL.Path})
+		resp.Body.Close()
+		resp.StatusCode = http.StatusOK
+		resp.Status = http.StatusText(http.StatusOK)
+		resp.Header.Set("ETag", cached.etag)
+		resp.Header.Del("Content-Encoding")
+		resp.Body = io.NopCloser(bytes.NewReader(cached.body))
+		resp.ContentLength = int64(len(cached.body))
+		return resp, nil
+	}
+
+	tflog.SubsystemDebug(req.Context(), LogSubsystemCache, "Not served from the ETag cache", map[string]interface{}{
+		"path":        req.URL.Path,
+		"had_etag":    hasCached,
+		"status_code": resp.StatusCode,
+	})
+
+	resp, err = decompressResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.etagMu.Lock()
+	if c.cache.etagCache == nil {
+		c.cache.etagCache = map[string]etagEntry{}
+	}
+	c.cache.etagCache[c.cacheKey(endpoint)] = etagEntry{etag: etag, body: body}
+	c.cache.etagMu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
 }
 
 // DoRequestWithHeadersAndCookies performs an HTTP request with additional headers and cookies.
@@ -126,12 +551,11 @@ func (c *Client) DoRequestWithHeadersAndCookies(method, endpoint string, payload
 		}
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(c.context(), method, url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
@@ -139,12 +563,32 @@ func (c *Client) DoRequestWithHeadersAndCookies(method, endpoint string, payload
 		req.AddCookie(cookie)
 	}
 
-	client := &http.Client{}
-	return client.Do(req)
+	return c.do(req)
 }
 
-// GetCSRFToken retrieves the CSRF token.
+// GetCSRFToken retrieves the CSRF token. Concurrent callers share a single fetch, so parallel
+// writes do not each start a new server-side session.
 func (c *Client) GetCSRFToken() (string, []*http.Cookie, error) {
+	if c.session == nil {
+		return c.fetchCSRFToken()
+	}
+
+	value, err, _ := c.session.csrf.Do("csrf", func() (interface{}, error) {
+		token, cookies, err := c.fetchCSRFToken()
+		if err != nil {
+			return nil, err
+		}
+		return csrfResult{token: token, cookies: cookies}, nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	result := value.(csrfResult)
+	return result.token, result.cookies, nil
+}
+
+// fetchCSRFToken requests a new CSRF token together with the session cookies it is bound to.
+func (c *Client) fetchCSRFToken() (string, []*http.Cookie, error) {
 	headers := map[string]string{
 		"Referer": c.Host,
 	}
@@ -208,7 +652,7 @@ func (c *Client) GetRoleIDByName(roleName string) (int64, error) {
 		}
 	}
 
-	return 0, fmt.Errorf("role %s not found", roleName)
+	return 0, fmt.Errorf("role %s: %w", roleName, ErrNotFound)
 }
 
 // GetRolePermissions retrieves the permissions associated with a given role ID from Superset.
@@ -252,47 +696,117 @@ func (c *Client) GetRolePermissions(roleID int64) ([]Permission, error) {
 // - A slice of int64 IDs that match the provided permissions.
 // - An error if the request fails or the decoding of the response fails.
 func (c *Client) GetPermissionViewMenuIDs(permissions []map[string]string) ([]int64, error) {
-	url := fmt.Sprintf("%s/api/v1/security/permissions-resources/?q=(page_size:5000)", c.Host)
-	req, err := http.NewRequest("GET", url, nil)
+	resources, err := c.fetchPermissionResources()
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.Token)
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	var ids []int64
+	for _, perm := range permissions {
+		for _, res := range resources {
+			if res.Permission.Name == perm["permission"] && res.ViewMenu.Name == perm["view_menu"] {
+				ids = append(ids, res.ID)
+				break
+			}
+		}
+	}
+	return ids, nil
+}
+
+// FetchPermissions fetches every permission/view menu pair defined in Superset.
+func (c *Client) FetchPermissions() ([]Permission, error) {
+	resources, err := c.fetchPermissionResources()
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch permissions resources from Superset, status code: %d", resp.StatusCode)
+	permissions := make([]Permission, 0, len(resources))
+	for _, resource := range resources {
+		permissions = append(permissions, Permission{
+			ID:             resource.ID,
+			PermissionName: resource.Permission.Name,
+			ViewMenuName:   resource.ViewMenu.Name,
+		})
 	}
+	return permissions, nil
+}
 
-	var result struct {
-		Resources []struct {
-			ID         int64 `json:"id"`
-			Permission struct {
-				Name string `json:"name"`
-			} `json:"permission"`
-			ViewMenu struct {
-				Name string `json:"name"`
-			} `json:"view_menu"`
-		} `json:"result"`
+// permissionResourcesPageSize is the page size requested from the permissions-resources endpoint.
+// Superset caps it at FAB_API_MAX_PAGE_SIZE, so pagination relies on the returned count.
+const permissionResourcesPageSize = 5000
+
+// fetchPermissionResources fetches the full list of permission/view menu pairs from Superset,
+// following pagination until every row reported by the count has been retrieved.
+func (c *Client) fetchPermissionResources() ([]permissionResource, error) {
+	var resources []permissionResource
+	for page := 0; ; page++ {
+		pageResources, count, err := c.fetchPermissionResourcesPage(page)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, pageResources...)
+
+		if len(pageResources) == 0 {
+			break
+		}
+		if count > 0 && len(resources) >= count {
+			break
+		}
+		if count == 0 && len(pageResources) < permissionResourcesPageSize {
+			break
+		}
 	}
-	err = json.NewDecoder(resp.Body).Decode(&result)
+	return resources, nil
+}
+
+// permissionResourcesPage is a page of permission/view menu pairs together with the total count.
+type permissionResourcesPage struct {
+	Count     int                  `json:"count"`
+	Resources []permissionResource `json:"result"`
+}
+
+// fetchPermissionResourcesPage fetches a single page of permission/view menu pairs together with the total count.
+// The response is decoded straight from the (possibly gzip-compressed) body stream, and the
+// decoded page is kept per endpoint and ETag so an unchanged page is not decoded again.
+func (c *Client) fetchPermissionResourcesPage(page int) ([]permissionResource, int, error) {
+	endpoint := fmt.Sprintf("/api/v1/security/permissions-resources?q=(page:%d,page_size:%d)", page, permissionResourcesPageSize)
+	value, statusCode, err := c.decodedGet(endpoint, func(body io.Reader) (interface{}, error) {
+		var result permissionResourcesPage
+		err := json.NewDecoder(body).Decode(&result)
+		return result, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("failed to fetch permissions resources from Superset, status code: %d", statusCode)
+	}
+
+	result := value.(permissionResourcesPage)
+	return result.Resources, result.Count, nil
+}
+
+// GetPermissionIDsByNameAndView resolves many permission/view menu pairs with a single fetch of the
+// permissions resources. It returns the IDs of the pairs that exist, keyed by pair; pairs missing
+// from Superset are absent from the returned map, leaving it to the caller to decide how to report them.
+func (c *Client) GetPermissionIDsByNameAndView(pairs []PermissionPair) (map[PermissionPair]int64, error) {
+	resources, err := c.fetchPermissionResources()
 	if err != nil {
 		return nil, err
 	}
 
-	var ids []int64
-	for _, perm := range permissions {
-		for _, res := range result.Resources {
-			if res.Permission.Name == perm["permission"] && res.ViewMenu.Name == perm["view_menu"] {
-				ids = append(ids, res.ID)
-				break
-			}
+	index := make(map[PermissionPair]int64, len(resources))
+	for _, resource := range resources {
+		pair := PermissionPair{Permission: resource.Permission.Name, ViewMenu: resource.ViewMenu.Name}
+		if _, exists := index[pair]; !exists {
+			index[pair] = resource.ID
+		}
+	}
+
+	ids := make(map[PermissionPair]int64, len(pairs))
+	for _, pair := range pairs {
+		if id, ok := index[pair]; ok {
+			ids[pair] = id
 		}
 	}
 	return ids, nil
@@ -300,43 +814,55 @@ func (c *Client) GetPermissionViewMenuIDs(permissions []map[string]string) ([]in
 
 // CreateRole creates a role with the specified name in the Superset application.
 // If the role already exists, it returns the existing role ID.
-// It returns the ID of the created role and any error encountered.
-func (c *Client) CreateRole(name string) (int64, error) {
+// It returns the ID of the role, whether this call created it, and any error encountered. A role found
+// after a create whose outcome is unknown is not reported as created, since another client may have
+// created it in between, so callers never roll back a role they cannot be sure they created.
+func (c *Client) CreateRole(name string) (int64, bool, error) {
 	// Check if role already exists
 	existingID, err := c.GetRoleIDByName(name)
 	if err == nil {
-		return existingID, nil
+		return existingID, false, nil
 	}
 
 	endpoint := "/api/v1/security/roles/"
 	payload := map[string]string{"name": name}
-	resp, err := c.DoRequest("POST", endpoint, payload)
+	resp, err := c.forCreate().DoRequest("POST", endpoint, payload)
+	if errors.Is(err, ErrOutcomeUnknown) {
+		// The role may have been created although the response was lost, so it is looked up before creating it again.
+		existingID, lookupErr := c.GetRoleIDByName(name)
+		if lookupErr == nil {
+			return existingID, false, nil
+		}
+		if errors.Is(lookupErr, ErrNotFound) {
+			resp, err = c.forCreate().DoRequest("POST", endpoint, payload)
+		}
+	}
 	if err != nil {
-		return 0, err
+		return 0, false, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
 		body, _ := io.ReadAll(resp.Body) // Read the response body
-		return 0, fmt.Errorf("failed to create role, status code: %d, response: %s", resp.StatusCode, string(body))
+		return 0, false, responseError("create role", resp, body)
 	}
 
 	var result map[string]interface{}
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
-		return 0, err
+		return 0, false, err
 	}
 
 	id, ok := result["id"].(int64)
 	if !ok {
 		idFloat, okFloat := result["id"].(float64)
 		if !okFloat {
-			return 0, fmt.Errorf("failed to retrieve role ID from response")
+			return 0, false, fmt.Errorf("failed to retrieve role ID from response")
 		}
 		id = int64(idFloat)
 	}
 
-	return id, nil
+	return id, true, nil
 }
 
 // GetRole retrieves a role by its ID from the Superset API.
@@ -352,9 +878,12 @@ func (c *Client) GetRole(id int64) (*Role, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("role %d: %w", id, ErrNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body) // Read the response body for detailed error logging
-		return nil, fmt.Errorf("failed to fetch role, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("failed to fetch role, status code: %d, response: %s", resp.StatusCode, Scrub(string(body)))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -385,34 +914,142 @@ func (c *Client) GetRole(id int64) (*Role, error) {
 	return role, nil
 }
 
-// UpdateRole updates the name of a role with the specified ID.
-// If the role with the given ID does not exist, an error is returned.
-// If the existing role already has the specified name, no update is performed.
-// The updated role name is sent to the Superset API using a PUT request.
-// If the update is successful, the function returns nil.
-// If the update fails, an error is returned with the corresponding status code and response body.
-func (c *Client) UpdateRole(id int64, name string) error {
-	existingRole, err := c.GetRole(id)
+// GetRoleUsers retrieves the usernames of the users assigned to the role with the given ID, sorted by name.
+// It sends a GET request to the "/api/v1/security/roles/{id}/users" endpoint, and returns an error
+// wrapping errors.ErrUnsupported when Superset answers 405 Method Not Allowed.
+func (c *Client) GetRoleUsers(id int64) ([]string, error) {
+	endpoint := fmt.Sprintf("/api/v1/security/roles/%d/users", id)
+	resp, err := c.DoRequest("GET", endpoint, nil)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("error making GET request to %s: %v", endpoint, err)
 	}
+	defer resp.Body.Close()
 
-	if existingRole.Name == name {
-		fmt.Printf("Role with ID %d already has the name '%s'. No update necessary.\n", id, name)
-		return nil
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("role %d: %w", id, ErrNotFound)
+	}
+	if resp.StatusCode == http.StatusMethodNotAllowed {
+		return nil, fmt.Errorf("users of role %d: %w", id, errors.ErrUnsupported)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch users of role %d, status code: %d, response: %s", id, resp.StatusCode, Scrub(string(body)))
 	}
 
-	endpoint := fmt.Sprintf("/api/v1/security/roles/%d", id)
-	payload := map[string]string{"name": name}
-	resp, err := c.DoRequest("PUT", endpoint, payload)
+	var result struct {
+		Result []relatedUser `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("error unmarshalling response to struct: %v", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body) // Read the response body
-		return fmt.Errorf("failed to update role, status code: %d, response: %s", resp.StatusCode, string(body))
+	usernames := make([]string, 0, len(result.Result))
+	for _, user := range result.Result {
+		usernames = append(usernames, user.Username)
+	}
+	sort.Strings(usernames)
+
+	return usernames, nil
+}
+
+// CurrentUser represents the user the client is authenticated as.
+type CurrentUser struct {
+	ID        int64    `json:"id"`
+	Username  string   `json:"username"`
+	FirstName string   `json:"first_name"`
+	LastName  string   `json:"last_name"`
+	Email     string   `json:"email"`
+	IsActive  bool     `json:"is_active"`
+	Roles     []string `json:"-"`
+}
+
+// GetCurrentUser retrieves the user the client is authenticated as, with the names of its roles sorted by name.
+// It sends GET requests to the "/api/v1/me/" and "/api/v1/me/roles/" endpoints.
+func (c *Client) GetCurrentUser() (*CurrentUser, error) {
+	endpoint := "/api/v1/me/"
+	resp, err := c.DoRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error making GET request to %s: %v", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, responseError("fetch current user", resp, body)
+	}
+
+	var result struct {
+		Result CurrentUser `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error unmarshalling response to struct: %v", err)
+	}
+	user := result.Result
+
+	endpoint = "/api/v1/me/roles/"
+	rolesResp, err := c.DoRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error making GET request to %s: %v", endpoint, err)
+	}
+	defer rolesResp.Body.Close()
+
+	if rolesResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(rolesResp.Body)
+		return nil, responseError("fetch roles of current user", rolesResp, body)
+	}
+
+	// The roles are returned as a map from role name to the [permission, view menu] pairs of the role.
+	var roles struct {
+		Result struct {
+			Roles map[string]json.RawMessage `json:"roles"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(rolesResp.Body).Decode(&roles); err != nil {
+		return nil, fmt.Errorf("error unmarshalling response to struct: %v", err)
+	}
+
+	user.Roles = make([]string, 0, len(roles.Result.Roles))
+	for name := range roles.Result.Roles {
+		user.Roles = append(user.Roles, name)
+	}
+	sort.Strings(user.Roles)
+
+	return &user, nil
+}
+
+// UpdateRole updates the name of a role with the specified ID.
+// If the role with the given ID does not exist, an error is returned.
+// If the existing role already has the specified name, no update is performed.
+// The updated role name is sent to the Superset API using a PUT request.
+// If the update is successful, the function returns nil.
+// If the update fails, an error is returned with the corresponding status code and response body.
+func (c *Client) UpdateRole(id int64, name string) error {
+	existingRole, err := c.GetRole(id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.refuseBuiltInRole(existingRole, "rename"); err != nil {
+		return err
+	}
+
+	if existingRole.Name == name {
+		fmt.Printf("Role with ID %d already has the name '%s'. No update necessary.\n", id, name)
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/security/roles/%d", id)
+	payload := map[string]string{"name": name}
+	resp, err := c.DoRequest("PUT", endpoint, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body) // Read the response body
+		return responseError("update role", resp, body)
 	}
 
 	fmt.Printf("Role with ID %d successfully updated to name '%s'.\n", id, name)
@@ -425,6 +1062,10 @@ func (c *Client) UpdateRole(id int64, name string) error {
 // If there is an error or the response status code is not 204 (No Content) or 200 (OK),
 // it returns an error with the corresponding status code and response body.
 func (c *Client) DeleteRole(id int64) error {
+	if err := c.refuseBuiltInRoleID(id, "delete"); err != nil {
+		return err
+	}
+
 	endpoint := fmt.Sprintf("/api/v1/security/roles/%d", id)
 	resp, err := c.DoRequest("DELETE", endpoint, nil)
 	if err != nil {
@@ -434,12 +1075,39 @@ func (c *Client) DeleteRole(id int64) error {
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body) // Read the response body
-		return fmt.Errorf("failed to delete role, status code: %d, response: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to delete role, status code: %d, response: %s", resp.StatusCode, Scrub(string(body)))
 	}
 
 	return nil
 }
 
+// refuseBuiltInRoleID returns an error wrapping ErrBuiltInRole when the role of the given ID is a built-in
+// role, unless AllowBuiltInRoleChanges is set. A missing role is left for the change itself to report.
+func (c *Client) refuseBuiltInRoleID(id int64, operation string) error {
+	if c.AllowBuiltInRoleChanges {
+		return nil
+	}
+
+	role, err := c.GetRole(id)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return c.refuseBuiltInRole(role, operation)
+}
+
+// refuseBuiltInRole returns an error wrapping ErrBuiltInRole when role is a built-in role, unless
+// AllowBuiltInRoleChanges is set.
+func (c *Client) refuseBuiltInRole(role *Role, operation string) error {
+	if c.AllowBuiltInRoleChanges || !slices.Contains(BuiltInRoleNames, role.Name) {
+		return nil
+	}
+	return fmt.Errorf("refusing to %s role %q (ID %d): it is a %w of Superset, set allow_builtin_role_changes "+
+		"in the provider configuration to change it anyway", operation, role.Name, role.ID, ErrBuiltInRole)
+}
+
 // GetPermissionIDByNameAndView retrieves the ID of a permission by its name and view menu name.
 // It sends a GET request to the Superset API to fetch the permissions resources and searches for the resource
 // that matches the given permission name and view menu name. If a match is found, it returns the ID of the resource.
@@ -453,48 +1121,116 @@ func (c *Client) DeleteRole(id int64) error {
 // - int64: The ID of the permission resource if found.
 // - error: An error if the request fails or if the permission resource is not found.
 func (c *Client) GetPermissionIDByNameAndView(permissionName, viewMenuName string) (int64, error) {
-	endpoint := "/api/v1/security/permissions-resources?q=(page_size:5000)"
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resources, err := c.fetchPermissionResources()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, resource := range resources {
+		if resource.Permission.Name == permissionName && resource.ViewMenu.Name == viewMenuName {
+			return resource.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("permission %s with view menu %s: %w", permissionName, viewMenuName, ErrNotFound)
+}
+
+// EnsurePermissionView returns the ID of the permission/view menu pair, creating the permission,
+// the view menu and the pair through the FAB security API when they do not exist yet.
+// Plugins may only register their permissions on first access, which this lets Terraform anticipate.
+func (c *Client) EnsurePermissionView(permissionName, viewMenuName string) (int64, error) {
+	id, err := c.GetPermissionIDByNameAndView(permissionName, viewMenuName)
+	if err == nil || !errors.Is(err, ErrNotFound) {
+		return id, err
+	}
+
+	permissionID, err := c.ensureSecurityObject("permissions", permissionName)
+	if err != nil {
+		return 0, err
+	}
+	viewMenuID, err := c.ensureSecurityObject("view-menus", viewMenuName)
+	if err != nil {
+		return 0, err
+	}
+
+	payload := map[string]int64{"permission_id": permissionID, "view_menu_id": viewMenuID}
+	return c.createSecurityObject("permissions-resources", payload)
+}
+
+// ensureSecurityObject returns the ID of the named permission or view menu (kind "permissions" or
+// "view-menus"), creating it when it does not exist.
+func (c *Client) ensureSecurityObject(kind, name string) (int64, error) {
+	resp, err := c.DoRequest("GET", fmt.Sprintf("/api/v1/security/%s/?q=(page_size:5000)", kind), nil)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("failed to fetch permissions resources from Superset, status code: %d", resp.StatusCode)
+		return 0, fmt.Errorf("failed to fetch %s from Superset, status code: %d", kind, resp.StatusCode)
 	}
 
 	var result struct {
-		Resources []struct {
-			ID         int64 `json:"id"`
-			Permission struct {
-				Name string `json:"name"`
-			} `json:"permission"`
-			ViewMenu struct {
-				Name string `json:"name"`
-			} `json:"view_menu"`
+		Result []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
 		} `json:"result"`
 	}
-
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
 		return 0, err
 	}
 
-	for _, resource := range result.Resources {
-		if resource.Permission.Name == permissionName && resource.ViewMenu.Name == viewMenuName {
-			return resource.ID, nil
+	for _, object := range result.Result {
+		if object.Name == name {
+			return object.ID, nil
 		}
 	}
 
-	return 0, fmt.Errorf("permission %s with view menu %s not found", permissionName, viewMenuName)
+	return c.createSecurityObject(kind, map[string]string{"name": name})
+}
+
+// createSecurityObject creates a permission, view menu or permission/view menu pair and returns its ID.
+func (c *Client) createSecurityObject(kind string, payload interface{}) (int64, error) {
+	resp, err := c.DoRequest("POST", fmt.Sprintf("/api/v1/security/%s/", kind), payload)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("failed to create %s, status code: %d, response: %s", kind, resp.StatusCode, Scrub(string(body)))
+	}
+
+	var result struct {
+		ID int64 `json:"id"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return 0, err
+	}
+	if result.ID == 0 {
+		return 0, fmt.Errorf("failed to retrieve the ID of the created %s from the response", kind)
+	}
+
+	return result.ID, nil
 }
 
 // UpdateRolePermissions updates the permissions of a role in the Superset application.
 // It takes the role ID and a slice of permission IDs as parameters.
 // The function sends a POST request to the Superset API to update the role permissions.
 // It returns an error if the request fails or if the response status code is not 200 OK.
+// The permissions of the built-in roles are only replaced when AllowBuiltInRoleChanges is set.
 func (c *Client) UpdateRolePermissions(roleID int64, permissionIDs []int64) error {
+	if err := c.refuseBuiltInRoleID(roleID, "replace the permissions of"); err != nil {
+		return err
+	}
+	return c.setRolePermissions(roleID, permissionIDs)
+}
+
+// setRolePermissions sends the permissions of a role to Superset, without the built-in role guard.
+func (c *Client) setRolePermissions(roleID int64, permissionIDs []int64) error {
 	url := fmt.Sprintf("%s/api/v1/security/roles/%d/permissions", c.Host, roleID)
 	data := map[string][]int64{"permission_view_menu_ids": permissionIDs}
 	jsonData, err := json.Marshal(data)
@@ -502,14 +1238,13 @@ func (c *Client) UpdateRolePermissions(roleID int64, permissionIDs []int64) erro
 		return err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(c.context(), "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.Token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -517,9 +1252,76 @@ func (c *Client) UpdateRolePermissions(roleID int64, permissionIDs []int64) erro
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update role permissions, status code: %d, response: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to update role permissions, status code: %d, response: %s", resp.StatusCode, Scrub(string(body)))
+	}
+
+	return nil
+}
+
+// UpdateRolePermissionsInBatches replaces the permissions of a role like UpdateRolePermissions, for roles
+// too large to be updated in a single request behind a proxy with a short timeout. The permissions that
+// are no longer wanted are revoked first, then each request adds at most batchSize of the missing ones to
+// those already applied. Superset has no endpoint adding permissions to a role: every request replaces the
+// whole list, so the last one still carries every ID and Superset still loads every permission it names.
+// Only the grants written per request are bounded, and only because SQLAlchemy diffs the assigned
+// collection against the stored one and inserts just the new rows. The permissions are read back at the
+// end, and an error is returned if they differ from permissionIDs.
+func (c *Client) UpdateRolePermissionsInBatches(roleID int64, permissionIDs []int64, batchSize int) error {
+	if err := c.refuseBuiltInRoleID(roleID, "replace the permissions of"); err != nil {
+		return err
+	}
+
+	granted, err := c.GetRolePermissions(roleID)
+	if err != nil {
+		return err
+	}
+	grantedIDs := make(map[int64]bool, len(granted))
+	for _, permission := range granted {
+		grantedIDs[permission.ID] = true
+	}
+
+	var applied, missing []int64
+	for _, id := range permissionIDs {
+		if grantedIDs[id] {
+			applied = append(applied, id)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(applied) < len(granted) {
+		if err := c.setRolePermissions(roleID, applied); err != nil {
+			return fmt.Errorf("revoking permissions: %w", err)
+		}
+	}
+	batches := (len(missing) + batchSize - 1) / batchSize
+	for batch := 0; batch < batches; batch++ {
+		start := batch * batchSize
+		applied = append(applied, missing[start:min(start+batchSize, len(missing))]...)
+		if err := c.setRolePermissions(roleID, applied); err != nil {
+			return fmt.Errorf("batch %d of %d: %w", batch+1, batches, err)
+		}
 	}
 
+	granted, err = c.GetRolePermissions(roleID)
+	if err != nil {
+		return fmt.Errorf("verifying the permissions: %w", err)
+	}
+	wanted := make(map[int64]bool, len(permissionIDs))
+	for _, id := range permissionIDs {
+		wanted[id] = true
+	}
+	var unexpected []int64
+	for _, permission := range granted {
+		if !wanted[permission.ID] {
+			unexpected = append(unexpected, permission.ID)
+		}
+		delete(wanted, permission.ID)
+	}
+	if len(wanted) > 0 || len(unexpected) > 0 {
+		return fmt.Errorf("the permissions of role %d differ after the batched update: %d not granted, %d not revoked %v",
+			roleID, len(wanted), len(unexpected), unexpected)
+	}
 	return nil
 }
 
@@ -527,6 +1329,10 @@ func (c *Client) UpdateRolePermissions(roleID int64, permissionIDs []int64) erro
 // It sends a POST request to the Superset API to update the role's permissions.
 // The function returns an error if the request fails or if the response status code is not 200 OK.
 func (c *Client) ClearRolePermissions(roleID int64) error {
+	if err := c.refuseBuiltInRoleID(roleID, "clear the permissions of"); err != nil {
+		return err
+	}
+
 	endpoint := fmt.Sprintf("/api/v1/security/roles/%d/permissions", roleID)
 	payload := map[string]interface{}{
 		"permission_view_menu_ids": []int64{},
@@ -539,17 +1345,41 @@ func (c *Client) ClearRolePermissions(roleID int64) error {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body) // Read the response body
-		return fmt.Errorf("failed to clear role permissions, status code: %d, response: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to clear role permissions, status code: %d, response: %s", resp.StatusCode, Scrub(string(body)))
 	}
 
 	return nil
 }
 
-// FetchRoles fetches the roles from the Superset API.
-// It sends a GET request to the "/api/v1/security/roles?q=(page_size:5000)" endpoint
-// and returns a slice of rawRoleModel and an error.
-func (c *Client) FetchRoles() ([]rawRoleModel, error) {
-	endpoint := "/api/v1/security/roles?q=(page_size:5000)"
+// UpdateRoleUsers replaces the users assigned to a role with the users of the given IDs.
+// It sends a PUT request to the "/api/v1/security/roles/{id}/users" endpoint; an empty slice removes every user from the role.
+func (c *Client) UpdateRoleUsers(roleID int64, userIDs []int64) error {
+	endpoint := fmt.Sprintf("/api/v1/security/roles/%d/users", roleID)
+	if userIDs == nil {
+		userIDs = []int64{}
+	}
+	payload := map[string][]int64{"user_ids": userIDs}
+	resp, err := c.DoRequest("PUT", endpoint, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("role %d: %w", roleID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update role users, status code: %d, response: %s", resp.StatusCode, Scrub(string(body)))
+	}
+
+	return nil
+}
+
+// GetUserIDsByUsername looks up the IDs of the users with the given usernames with a single fetch.
+// It returns an error naming every username that does not exist in Superset.
+func (c *Client) GetUserIDsByUsername(usernames []string) (map[string]int64, error) {
+	endpoint := "/api/v1/security/users/?q=(columns:!(id,username),page_size:5000)"
 	resp, err := c.DoRequest("GET", endpoint, nil)
 	if err != nil {
 		return nil, err
@@ -557,74 +1387,138 @@ func (c *Client) FetchRoles() ([]rawRoleModel, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch roles from Superset, status code: %d", resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch users from Superset, status code: %d, response: %s", resp.StatusCode, Scrub(string(body)))
 	}
 
 	var result struct {
-		Roles []rawRoleModel `json:"result"`
+		Result []relatedUser `json:"result"`
 	}
-
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
 		return nil, err
 	}
 
-	return result.Roles, nil
+	known := make(map[string]int64, len(result.Result))
+	for _, user := range result.Result {
+		known[user.Username] = user.ID
+	}
+
+	ids := make(map[string]int64, len(usernames))
+	var missing []string
+	for _, username := range usernames {
+		id, ok := known[username]
+		if !ok {
+			missing = append(missing, username)
+			continue
+		}
+		ids[username] = id
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("users not found: %s", strings.Join(missing, ", "))
+	}
+
+	return ids, nil
 }
 
-// GetDatabaseSchemasByID retrieves the database schemas by the given database ID.
-// It makes a GET request to the Superset API and returns a list of schema names.
-// If the request fails or the response status code is not 200 OK, an error is returned.
-func (c *Client) GetDatabaseSchemasByID(databaseID int64) ([]string, error) {
-	endpoint := fmt.Sprintf("/api/v1/database/%d/schemas/", databaseID)
+// FetchRoles fetches the roles from the Superset API with their permission IDs. The list endpoint of FAB only
+// returns the id and name of roles whatever the columns parameter asks for, so the permissions are read from
+// the "/api/v1/security/roles/search/" endpoint of Superset 5. Older versions lack it, and their roles are
+// returned without permissions rather than with one request per role. It returns the roles, the total number
+// of roles Superset reported, which is larger when the list was truncated, whether the permissions of the
+// roles were listed, and an error.
+func (c *Client) FetchRoles() ([]rawRoleModel, int, bool, error) {
+	roles, count, withPermissions, err := c.searchRoles()
+	if errors.Is(err, ErrNotFound) {
+		roles, count, err = c.listRoles()
+	}
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return roles, count, withPermissions, nil
+}
+
+// searchRoles fetches the roles with their permission IDs from the search endpoint added in Superset 5,
+// and reports whether every role came with its permissions. It returns an error wrapping ErrNotFound
+// when the endpoint does not exist.
+func (c *Client) searchRoles() ([]rawRoleModel, int, bool, error) {
+	endpoint := "/api/v1/security/roles/search/?q=(page_size:5000)"
 	resp, err := c.DoRequest("GET", endpoint, nil)
 	if err != nil {
-		return nil, err
+		return nil, 0, false, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return nil, 0, false, fmt.Errorf("role search endpoint: %w", ErrNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch schemas from Superset, status code: %d", resp.StatusCode)
+		return nil, 0, false, fmt.Errorf("failed to search roles in Superset, status code: %d", resp.StatusCode)
 	}
 
 	var result struct {
-		Result []string `json:"result"`
+		Count int `json:"count"`
+		Roles []struct {
+			ID            int64    `json:"id"`
+			Name          string   `json:"name"`
+			PermissionIDs *[]int64 `json:"permission_ids"`
+		} `json:"result"`
 	}
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
-		return nil, err
+		return nil, 0, false, err
 	}
 
-	return result.Result, nil
+	withPermissions := true
+	roles := make([]rawRoleModel, 0, len(result.Roles))
+	for _, role := range result.Roles {
+		// A missing field would otherwise be reported as a role without permissions.
+		if role.PermissionIDs == nil {
+			withPermissions = false
+			roles = append(roles, rawRoleModel{ID: role.ID, Name: role.Name})
+			continue
+		}
+		permissions := make([]relatedItem, 0, len(*role.PermissionIDs))
+		for _, id := range *role.PermissionIDs {
+			permissions = append(permissions, relatedItem{ID: id})
+		}
+		roles = append(roles, rawRoleModel{ID: role.ID, Name: role.Name, Permissions: permissions})
+	}
+
+	return roles, result.Count, withPermissions, nil
 }
 
-// GetDatabaseConnectionByID retrieves the database connection information by its ID from Superset.
-// It makes a GET request to the Superset API and returns the response as a map[string]interface{}.
-// If the request fails or the response status code is not 200 OK, an error is returned.
-func (c *Client) GetDatabaseConnectionByID(databaseID int64) (map[string]interface{}, error) {
-	endpoint := fmt.Sprintf("/api/v1/database/%d/connection", databaseID)
+// listRoles fetches the id and name of the roles from the list endpoint, for Superset versions without
+// the role search endpoint.
+func (c *Client) listRoles() ([]rawRoleModel, int, error) {
+	endpoint := "/api/v1/security/roles?q=(page_size:5000)"
 	resp, err := c.DoRequest("GET", endpoint, nil)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch database connection from Superset, status code: %d", resp.StatusCode)
+		return nil, 0, fmt.Errorf("failed to fetch roles from Superset, status code: %d", resp.StatusCode)
 	}
 
-	var result map[string]interface{}
+	var result struct {
+		Count int            `json:"count"`
+		Roles []rawRoleModel `json:"result"`
+	}
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return result, nil
+	return result.Roles, result.Count, nil
 }
 
-// GetAllDatabases retrieves all databases from Superset.
-func (c *Client) GetAllDatabases() ([]map[string]interface{}, error) {
-	endpoint := "/api/v1/database/"
+// FetchRoleUsernames returns the usernames of the users of each role, keyed by role ID, from the roles
+// the users endpoint lists for every user. That endpoint needs the permission to list users, which an
+// account allowed to list roles may lack.
+func (c *Client) FetchRoleUsernames() (map[int64][]string, error) {
+	endpoint := "/api/v1/security/users/?q=(columns:!(id,username,roles.id),page_size:5000)"
 	resp, err := c.DoRequest("GET", endpoint, nil)
 	if err != nil {
 		return nil, err
@@ -632,42 +1526,166 @@ func (c *Client) GetAllDatabases() ([]map[string]interface{}, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch databases from Superset, status code: %d", resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch users from Superset, status code: %d, response: %s", resp.StatusCode, Scrub(string(body)))
 	}
 
 	var result struct {
-		Result []map[string]interface{} `json:"result"`
+		Result []struct {
+			ID       int64          `json:"id"`
+			Username string         `json:"username"`
+			Roles    *[]relatedItem `json:"roles"`
+		} `json:"result"`
 	}
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
 		return nil, err
 	}
 
-	return result.Result, nil
+	usersByRole := map[int64][]string{}
+	for _, user := range result.Result {
+		// A missing field would otherwise be reported as a user without roles.
+		if user.Roles == nil {
+			return nil, fmt.Errorf("the users endpoint of Superset did not return the roles of user %q", user.Username)
+		}
+		for _, role := range *user.Roles {
+			usersByRole[role.ID] = append(usersByRole[role.ID], user.Username)
+		}
+	}
+	return usersByRole, nil
 }
 
-// GetDatabasesInfos retrieves information about all databases.
-// It returns a map containing the details of each database, including the database ID, name, schemas, and SQLAlchemy URI.
-// If an error occurs during the retrieval process, it returns nil and the error.
-func (c *Client) GetDatabasesInfos() (map[string]interface{}, error) {
-	databasesInfo, err := c.GetAllDatabases()
+// FetchViewMenus fetches the view menus (the resources permissions are granted on) from the Superset API.
+// It sends a GET request to the "/api/v1/security/view-menus/" endpoint and returns the view menus,
+// the total number of view menus Superset reported, and an error.
+func (c *Client) FetchViewMenus() ([]ViewMenu, int, error) {
+	endpoint := "/api/v1/security/view-menus/?q=(page_size:5000)"
+	resp, err := c.DoRequest("GET", endpoint, nil)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	databasesList := []map[string]interface{}{}
+	defer resp.Body.Close()
 
-	for _, db := range databasesInfo {
-		dbID, ok := db["id"].(float64)
-		if !ok {
-			continue
-		}
-		databaseDetails, err := c.GetDatabaseConnectionByID(int64(dbID))
-		if err != nil {
-			return nil, err
-		}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("failed to fetch view menus from Superset, status code: %d", resp.StatusCode)
+	}
 
-		var sqlalchemyURI, databaseName string
-		if result, ok := databaseDetails["result"].(map[string]interface{}); ok {
+	var result struct {
+		Count     int        `json:"count"`
+		ViewMenus []ViewMenu `json:"result"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return result.ViewMenus, result.Count, nil
+}
+
+// GetDatabaseSchemasByID retrieves the database schemas by the given database ID.
+// It makes a GET request to the Superset API and returns a list of schema names.
+// If the request fails or the response status code is not 200 OK, an error is returned.
+func (c *Client) GetDatabaseSchemasByID(databaseID int64) ([]string, error) {
+	endpoint := fmt.Sprintf("/api/v1/database/%d/schemas/", databaseID)
+	resp, err := c.DoRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch schemas from Superset, status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Result []string `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// GetDatabaseConnectionByID retrieves the database connection information by its ID from Superset.
+// It makes a GET request to the Superset API and returns the response as a map[string]interface{}.
+// If the request fails or the response status code is not 200 OK, an error is returned.
+func (c *Client) GetDatabaseConnectionByID(databaseID int64) (map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("/api/v1/database/%d/connection", databaseID)
+	resp, err := c.DoRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("database connection %d: %w", databaseID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch database connection from Superset, status code: %d", resp.StatusCode)
+	}
+
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetAllDatabases retrieves the databases from Superset, along with the total number of databases
+// Superset reported, which is larger than the number returned when the list was truncated.
+func (c *Client) GetAllDatabases() ([]map[string]interface{}, int, error) {
+	endpoint := "/api/v1/database/"
+	resp, err := c.DoRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("failed to fetch databases from Superset, status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Count  int                      `json:"count"`
+		Result []map[string]interface{} `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return result.Result, result.Count, nil
+}
+
+// GetDatabasesInfos retrieves information about all databases.
+// It returns a map containing the details of each database, including the database ID, name, schemas, and SQLAlchemy URI,
+// under "databases", and the total number of databases Superset reported under "count".
+// Schemas are only fetched, with one extra request per database, when includeSchemas is true.
+// If an error occurs during the retrieval process, it returns nil and the error.
+func (c *Client) GetDatabasesInfos(includeSchemas bool) (map[string]interface{}, error) {
+	databasesInfo, count, err := c.GetAllDatabases()
+	if err != nil {
+		return nil, err
+	}
+	databasesList := []map[string]interface{}{}
+
+	for _, db := range databasesInfo {
+		dbID, ok := db["id"].(float64)
+		if !ok {
+			continue
+		}
+		databaseDetails, err := c.GetDatabaseConnectionByID(int64(dbID))
+		if err != nil {
+			return nil, err
+		}
+
+		var sqlalchemyURI, databaseName string
+		if result, ok := databaseDetails["result"].(map[string]interface{}); ok {
 			sqlalchemyURI, _ = result["sqlalchemy_uri"].(string)
 			databaseName, _ = result["database_name"].(string)
 		}
@@ -680,20 +1698,112 @@ func (c *Client) GetDatabasesInfos() (map[string]interface{}, error) {
 			databaseName = "Name not provided"
 		}
 
-		schemas, err := c.GetDatabaseSchemasByID(int64(dbID))
-		if err != nil {
-			return nil, err
-		}
-
-		databasesList = append(databasesList, map[string]interface{}{
+		info := map[string]interface{}{
 			"id":             int64(dbID),
 			"database_name":  databaseName,
-			"schemas":        schemas,
 			"sqlalchemy_uri": sqlalchemyURI,
-		})
+		}
+
+		if includeSchemas {
+			schemas, err := c.GetDatabaseSchemasByID(int64(dbID))
+			if err != nil {
+				return nil, err
+			}
+			info["schemas"] = schemas
+		}
+
+		databasesList = append(databasesList, info)
+	}
+
+	return map[string]interface{}{"databases": databasesList, "count": count}, nil
+}
+
+// GetDatabaseIDByUUID retrieves the ID of a database by its UUID.
+// It filters the database list endpoint on the uuid column, so only the matching row is returned.
+// If no database has the given UUID, an error wrapping ErrNotFound is returned.
+func (c *Client) GetDatabaseIDByUUID(uuid string) (int64, error) {
+	endpoint := fmt.Sprintf("/api/v1/database/?q=(columns:!(id,uuid),filters:!((col:uuid,opr:eq,value:'%s')))", uuid)
+	resp, err := c.DoRequest("GET", endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to fetch databases from Superset, status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Result []struct {
+			ID   int64  `json:"id"`
+			UUID string `json:"uuid"`
+		} `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, db := range result.Result {
+		if db.UUID == uuid {
+			return db.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("database with uuid %s: %w", uuid, ErrNotFound)
+}
+
+// GetDatabaseIDByName retrieves the ID of a database by its name.
+// It filters the database list endpoint on the database_name column, so only the matching rows
+// are returned instead of every connection of the instance.
+// If no database has the given name, an error wrapping ErrNotFound is returned, and if several
+// databases share it, an error wrapping ErrAmbiguousName that lists their IDs.
+func (c *Client) GetDatabaseIDByName(name string) (int64, error) {
+	filter := url.QueryEscape(risonString(name))
+	endpoint := fmt.Sprintf("/api/v1/database/?q=(columns:!(id,database_name),filters:!((col:database_name,opr:eq,value:%s)))", filter)
+	resp, err := c.DoRequest("GET", endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to fetch databases from Superset, status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Result []struct {
+			ID           int64  `json:"id"`
+			DatabaseName string `json:"database_name"`
+		} `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return 0, err
+	}
+
+	// The eq operator may compare case-insensitively depending on the metadata database collation.
+	var ids []int64
+	for _, db := range result.Result {
+		if db.DatabaseName == name {
+			ids = append(ids, db.ID)
+		}
 	}
 
-	return map[string]interface{}{"databases": databasesList}, nil
+	switch len(ids) {
+	case 0:
+		return 0, fmt.Errorf("database %s: %w", name, ErrNotFound)
+	case 1:
+		return ids[0], nil
+	default:
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		candidates := make([]string, 0, len(ids))
+		for _, id := range ids {
+			candidates = append(candidates, strconv.FormatInt(id, 10))
+		}
+		return 0, fmt.Errorf("database %s: %w, it is shared by the databases with IDs %s; refer to the database by its UUID or ID instead",
+			name, ErrAmbiguousName, strings.Join(candidates, ", "))
+	}
 }
 
 // CreateDatabase creates a new database in the Superset application.
@@ -710,7 +1820,7 @@ func (c *Client) CreateDatabase(payload map[string]interface{}) (map[string]inte
 		"Referer":     c.Host,
 	}
 
-	resp, err := c.DoRequestWithHeadersAndCookies("POST", "/api/v1/database/", payload, headers, cookies)
+	resp, err := c.forCreate().DoRequestWithHeadersAndCookies("POST", "/api/v1/database/", payload, headers, cookies)
 	if err != nil {
 		return nil, err
 	}
@@ -718,7 +1828,7 @@ func (c *Client) CreateDatabase(payload map[string]interface{}) (map[string]inte
 
 	if resp.StatusCode != http.StatusCreated {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create database, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, responseError("create database", resp, body)
 	}
 
 	var result map[string]interface{}
@@ -751,7 +1861,7 @@ func (c *Client) UpdateDatabase(databaseID int64, payload map[string]interface{}
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to update database, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, responseError("update database", resp, body)
 	}
 
 	var result map[string]interface{}
@@ -785,27 +1895,703 @@ func (c *Client) DeleteDatabase(databaseID int64) error {
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete database, status code: %d, response: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to delete database, status code: %d, response: %s", resp.StatusCode, Scrub(string(body)))
 	}
 
 	return nil
 }
 
-// rawRoleModel represents a raw role model in the Superset client.
-type rawRoleModel struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+// GetChartData executes the saved query of the chart with the given ID and returns its result.
+// It reads the query context Superset stored when the chart was last saved and posts it to the
+// chart data endpoint. Charts saved before query contexts existed must be re-saved in Superset first.
+func (c *Client) GetChartData(chartID int64) (*ChartData, error) {
+	resp, err := c.DoRequest("GET", fmt.Sprintf("/api/v1/chart/%d?q=(columns:!(id,query_context))", chartID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch chart %d, status code: %d, response: %s", chartID, resp.StatusCode, Scrub(string(body)))
+	}
+
+	var chart struct {
+		Result struct {
+			QueryContext *string `json:"query_context"`
+		} `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&chart)
+	if err != nil {
+		return nil, err
+	}
+
+	if chart.Result.QueryContext == nil || *chart.Result.QueryContext == "" {
+		return nil, fmt.Errorf("chart %d has no saved query context, open and save it in Superset to generate one", chartID)
+	}
+
+	csrfToken, cookies, err := c.GetCSRFToken()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{
+		"X-CSRFToken": csrfToken,
+		"Referer":     c.Host,
+	}
+
+	queryContext := json.RawMessage(*chart.Result.QueryContext)
+	dataResp, err := c.DoRequestWithHeadersAndCookies("POST", "/api/v1/chart/data", queryContext, headers, cookies)
+	if err != nil {
+		return nil, err
+	}
+	defer dataResp.Body.Close()
+
+	if dataResp.StatusCode == http.StatusAccepted {
+		return c.awaitChartData(chartID, dataResp)
+	}
+	if dataResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(dataResp.Body)
+		return nil, fmt.Errorf("failed to fetch data for chart %d, status code: %d, response: %s", chartID, dataResp.StatusCode, Scrub(string(body)))
+	}
+
+	return decodeChartData(chartID, dataResp)
 }
 
-// Permission represents a permission in the Superset application.
-type Permission struct {
-	ID             int64  `json:"id"`
-	PermissionName string `json:"permission_name"`
-	ViewMenuName   string `json:"view_menu_name"`
+// awaitChartData waits for the result of a chart data query Superset runs asynchronously, which it
+// answers with 202 Accepted and the URL the result is cached at once the query completes.
+func (c *Client) awaitChartData(chartID int64, accepted *http.Response) (*ChartData, error) {
+	var job struct {
+		ResultURL string `json:"result_url"`
+	}
+	if err := json.NewDecoder(accepted.Body).Decode(&job); err != nil {
+		return nil, err
+	}
+	if job.ResultURL == "" {
+		return nil, fmt.Errorf("chart %d was queued by Superset without a result URL", chartID)
+	}
+
+	var data *ChartData
+	err := c.poll(fmt.Sprintf("query of chart %d", chartID), func() (bool, error) {
+		resp, err := c.DoRequestWithoutCache("GET", job.ResultURL, nil)
+		if err != nil {
+			return false, err
+		}
+		defer resp.Body.Close()
+
+		// The result is only cached once the query completes.
+		if resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return false, fmt.Errorf("failed to fetch data for chart %d, status code: %d, response: %s", chartID, resp.StatusCode, Scrub(string(body)))
+		}
+
+		data, err = decodeChartData(chartID, resp)
+		return err == nil, err
+	})
+	return data, err
 }
 
-// Role represents a role in the Superset application.
-type Role struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+// decodeChartData decodes the first query result of a chart data response.
+func decodeChartData(chartID int64, resp *http.Response) (*ChartData, error) {
+	var result struct {
+		Result []ChartData `json:"result"`
+	}
+	err := json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Result) == 0 {
+		return nil, fmt.Errorf("chart %d returned no query results", chartID)
+	}
+
+	return &result.Result[0], nil
+}
+
+// CreateDashboardPermalink creates a permalink to the dashboard with the given ID or slug.
+// The state holds the dataMask (preselected filters), activeTabs, anchor and urlParams of the link.
+// Superset derives the key from the dashboard and state, so the same state yields the same permalink.
+func (c *Client) CreateDashboardPermalink(dashboardIDOrSlug string, state map[string]interface{}) (*DashboardPermalink, error) {
+	csrfToken, cookies, err := c.GetCSRFToken()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{
+		"X-CSRFToken": csrfToken,
+		"Referer":     c.Host,
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/dashboard/%s/permalink", url.PathEscape(dashboardIDOrSlug))
+	resp, err := c.DoRequestWithHeadersAndCookies("POST", endpoint, state, headers, cookies)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to create permalink for dashboard %s, status code: %d, response: %s", dashboardIDOrSlug, resp.StatusCode, Scrub(string(body)))
+	}
+
+	var permalink DashboardPermalink
+	err = json.NewDecoder(resp.Body).Decode(&permalink)
+	if err != nil {
+		return nil, err
+	}
+
+	return &permalink, nil
+}
+
+// RefreshDatasetColumns syncs the columns and metrics of the dataset with the given ID from its
+// underlying table, so columns renamed or added in the warehouse become visible in Superset.
+func (c *Client) RefreshDatasetColumns(datasetID int64) error {
+	csrfToken, cookies, err := c.GetCSRFToken()
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		"X-CSRFToken": csrfToken,
+		"Referer":     c.Host,
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/dataset/%d/refresh", datasetID)
+	resp, err := c.DoRequestWithHeadersAndCookies("PUT", endpoint, nil, headers, cookies)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("dataset %d: %w", datasetID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to refresh columns of dataset %d, status code: %d, response: %s", datasetID, resp.StatusCode, Scrub(string(body)))
+	}
+
+	return nil
+}
+
+// GetDatasetCharts returns the charts built on the dataset with the given ID, from the related objects
+// Superset reports for the dataset. If the dataset does not exist, an error wrapping ErrNotFound is returned.
+func (c *Client) GetDatasetCharts(datasetID int64) ([]Chart, error) {
+	resp, err := c.DoRequest("GET", fmt.Sprintf("/api/v1/dataset/%d/related_objects", datasetID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("dataset %d: %w", datasetID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch related objects of dataset %d, status code: %d, response: %s", datasetID, resp.StatusCode, Scrub(string(body)))
+	}
+
+	var result struct {
+		Charts struct {
+			Result []Chart `json:"result"`
+		} `json:"charts"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	charts := result.Charts.Result
+	sort.Slice(charts, func(i, j int) bool { return charts[i].ID < charts[j].ID })
+	return charts, nil
+}
+
+// GetDashboardJSONMetadata retrieves the decoded json_metadata of the dashboard with the given ID,
+// which holds among others its color_scheme and label_colors.
+func (c *Client) GetDashboardJSONMetadata(dashboardID int64) (map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("/api/v1/dashboard/%d?q=(columns:!(id,json_metadata))", dashboardID)
+	resp, err := c.DoRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("dashboard %d: %w", dashboardID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch dashboard %d, status code: %d, response: %s", dashboardID, resp.StatusCode, Scrub(string(body)))
+	}
+
+	var result struct {
+		Result struct {
+			JSONMetadata string `json:"json_metadata"`
+		} `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{}
+	if result.Result.JSONMetadata != "" {
+		err = json.Unmarshal([]byte(result.Result.JSONMetadata), &metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode json_metadata of dashboard %d: %v", dashboardID, err)
+		}
+	}
+
+	return metadata, nil
+}
+
+// UpdateDashboardJSONMetadata replaces the json_metadata of the dashboard with the given ID.
+func (c *Client) UpdateDashboardJSONMetadata(dashboardID int64, metadata map[string]interface{}) error {
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	csrfToken, cookies, err := c.GetCSRFToken()
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		"X-CSRFToken": csrfToken,
+		"Referer":     c.Host,
+	}
+
+	payload := map[string]string{"json_metadata": string(encoded)}
+	resp, err := c.DoRequestWithHeadersAndCookies("PUT", fmt.Sprintf("/api/v1/dashboard/%d", dashboardID), payload, headers, cookies)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("dashboard %d: %w", dashboardID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update dashboard %d, status code: %d, response: %s", dashboardID, resp.StatusCode, Scrub(string(body)))
+	}
+
+	return nil
+}
+
+// GetDashboardChartIDs returns the sorted IDs of the charts on the dashboard with the given ID.
+func (c *Client) GetDashboardChartIDs(dashboardID int64) ([]int64, error) {
+	resp, err := c.DoRequest("GET", fmt.Sprintf("/api/v1/dashboard/%d/charts", dashboardID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("dashboard %d: %w", dashboardID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch charts of dashboard %d, status code: %d, response: %s", dashboardID, resp.StatusCode, Scrub(string(body)))
+	}
+
+	var result struct {
+		Result []struct {
+			ID int64 `json:"id"`
+		} `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(result.Result))
+	for _, chart := range result.Result {
+		ids = append(ids, chart.ID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// GetChartDashboardIDs returns the IDs of the dashboards the chart with the given ID appears on.
+func (c *Client) GetChartDashboardIDs(chartID int64) ([]int64, error) {
+	resp, err := c.DoRequest("GET", fmt.Sprintf("/api/v1/chart/%d?q=(columns:!(id,dashboards.id))", chartID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("chart %d: %w", chartID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch chart %d, status code: %d, response: %s", chartID, resp.StatusCode, Scrub(string(body)))
+	}
+
+	var result struct {
+		Result struct {
+			Dashboards []struct {
+				ID int64 `json:"id"`
+			} `json:"dashboards"`
+		} `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(result.Result.Dashboards))
+	for _, dashboard := range result.Result.Dashboards {
+		ids = append(ids, dashboard.ID)
+	}
+	return ids, nil
+}
+
+// WarmUpChartCache runs the query of the chart with the given ID so its result is cached, the way
+// Superset's cache warmup tasks do. When dashboardID is not 0, the chart is warmed up with the default
+// filters of that dashboard. An error is returned when Superset reports that the query failed.
+func (c *Client) WarmUpChartCache(chartID, dashboardID int64) error {
+	csrfToken, cookies, err := c.GetCSRFToken()
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		"X-CSRFToken": csrfToken,
+		"Referer":     c.Host,
+	}
+
+	payload := map[string]int64{"chart_id": chartID}
+	if dashboardID != 0 {
+		payload["dashboard_id"] = dashboardID
+	}
+	resp, err := c.DoRequestWithHeadersAndCookies("PUT", "/api/v1/chart/warm_up_cache", payload, headers, cookies)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("chart %d: %w", chartID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to warm up the cache of chart %d, status code: %d, response: %s", chartID, resp.StatusCode, Scrub(string(body)))
+	}
+
+	var result struct {
+		Result []struct {
+			VizError *string `json:"viz_error"`
+		} `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return err
+	}
+
+	for _, warmup := range result.Result {
+		if warmup.VizError != nil && *warmup.VizError != "" {
+			return fmt.Errorf("failed to warm up the cache of chart %d: %s", chartID, Scrub(*warmup.VizError))
+		}
+	}
+	return nil
+}
+
+// UpdateChartDashboards replaces the dashboards the chart with the given ID appears on.
+func (c *Client) UpdateChartDashboards(chartID int64, dashboardIDs []int64) error {
+	csrfToken, cookies, err := c.GetCSRFToken()
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		"X-CSRFToken": csrfToken,
+		"Referer":     c.Host,
+	}
+
+	if dashboardIDs == nil {
+		dashboardIDs = []int64{}
+	}
+	payload := map[string][]int64{"dashboards": dashboardIDs}
+	resp, err := c.DoRequestWithHeadersAndCookies("PUT", fmt.Sprintf("/api/v1/chart/%d", chartID), payload, headers, cookies)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("chart %d: %w", chartID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update dashboards of chart %d, status code: %d, response: %s", chartID, resp.StatusCode, Scrub(string(body)))
+	}
+
+	return nil
+}
+
+// BatchDeleteDatasets deletes the datasets with the given IDs with a single bulk request.
+func (c *Client) BatchDeleteDatasets(ids []int64) error {
+	return c.batchDelete("dataset", ids)
+}
+
+// BatchDeleteCharts deletes the charts with the given IDs with a single bulk request.
+func (c *Client) BatchDeleteCharts(ids []int64) error {
+	return c.batchDelete("chart", ids)
+}
+
+// batchDelete deletes the objects of the given kind (e.g. "dataset", "chart") with a single
+// DELETE /api/v1/{kind}/?q=!(id1,id2,...) request. Superset deletes nothing and answers 404
+// when one of the IDs does not exist, which is reported as ErrNotFound.
+func (c *Client) batchDelete(kind string, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	csrfToken, cookies, err := c.GetCSRFToken()
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		"X-CSRFToken": csrfToken,
+		"Referer":     c.Host,
+	}
+
+	list := make([]string, len(ids))
+	for i, id := range ids {
+		list[i] = strconv.FormatInt(id, 10)
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/%s/?q=!(%s)", kind, strings.Join(list, ","))
+	resp, err := c.DoRequestWithHeadersAndCookies("DELETE", endpoint, nil, headers, cookies)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%ss %s: %w", kind, strings.Join(list, ", "), ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete %ss, status code: %d, response: %s", kind, resp.StatusCode, Scrub(string(body)))
+	}
+
+	return nil
+}
+
+// FetchQueries fetches the SQL Lab query history matching the filter, most recent first.
+// It sends a GET request to the "/api/v1/query/" endpoint.
+func (c *Client) FetchQueries(filter QueryFilter) ([]Query, error) {
+	var filters []string
+	if filter.UserID != 0 {
+		filters = append(filters, fmt.Sprintf("(col:user,opr:rel_o_m,value:%d)", filter.UserID))
+	}
+	if filter.DatabaseID != 0 {
+		filters = append(filters, fmt.Sprintf("(col:database,opr:rel_o_m,value:%d)", filter.DatabaseID))
+	}
+	if filter.Status != "" {
+		filters = append(filters, fmt.Sprintf("(col:status,opr:eq,value:%s)", risonString(filter.Status)))
+	}
+	// start_time is stored as milliseconds since the epoch.
+	if !filter.StartedAfter.IsZero() {
+		filters = append(filters, fmt.Sprintf("(col:start_time,opr:gt,value:%d)", filter.StartedAfter.UnixMilli()))
+	}
+	if !filter.StartedBefore.IsZero() {
+		filters = append(filters, fmt.Sprintf("(col:start_time,opr:lt,value:%d)", filter.StartedBefore.UnixMilli()))
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/query/?q=(filters:!(%s),order_column:start_time,order_direction:desc,page_size:%d)", strings.Join(filters, ","), limit)
+	resp, err := c.DoRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch queries from Superset, status code: %d, response: %s", resp.StatusCode, Scrub(string(body)))
+	}
+
+	var result struct {
+		Result []Query `json:"result"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// APIRequest sends a request with a raw JSON body to any endpoint of the Superset API and returns the raw
+// response body, for endpoints the provider does not model. Requests other than GET carry the CSRF token,
+// and GET requests bypass the client caches. A 404 returns an error wrapping ErrNotFound.
+func (c *Client) APIRequest(method, endpoint string, body json.RawMessage) ([]byte, error) {
+	// A nil json.RawMessage would be sent as "null", so only set the payload when there is a body.
+	var payload interface{}
+	if body != nil {
+		payload = body
+	}
+
+	var resp *http.Response
+	var err error
+	if method == http.MethodGet {
+		resp, err = c.DoRequestWithoutCache(method, endpoint, payload)
+	} else {
+		csrfToken, cookies, csrfErr := c.GetCSRFToken()
+		if csrfErr != nil {
+			return nil, csrfErr
+		}
+
+		headers := map[string]string{
+			"X-CSRFToken": csrfToken,
+			"Referer":     c.Host,
+		}
+		sender := c
+		if method == http.MethodPost {
+			sender = c.forCreate()
+		}
+		resp, err = sender.DoRequestWithHeadersAndCookies(method, endpoint, payload, headers, cookies)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s %s: %w", method, endpoint, ErrNotFound)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, responseError(method+" "+endpoint, resp, responseBody)
+	}
+
+	return responseBody, nil
+}
+
+// risonString quotes a string as a rison value.
+func risonString(value string) string {
+	escaped := strings.NewReplacer("!", "!!", "'", "!'").Replace(value)
+	return "'" + escaped + "'"
+}
+
+// rawRoleModel represents a raw role model in the Superset client.
+type rawRoleModel struct {
+	ID          int64         `json:"id"`
+	Name        string        `json:"name"`
+	Permissions []relatedItem `json:"permissions"`
+}
+
+// relatedUser represents a user related to a role.
+type relatedUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+// relatedItem represents a related object selected by ID through the columns parameter.
+type relatedItem struct {
+	ID int64 `json:"id"`
+}
+
+// permissionResource represents a permission/view menu pair as returned by the permissions-resources endpoint.
+type permissionResource struct {
+	ID         int64 `json:"id"`
+	Permission struct {
+		Name string `json:"name"`
+	} `json:"permission"`
+	ViewMenu struct {
+		Name string `json:"name"`
+	} `json:"view_menu"`
+}
+
+// PermissionPair identifies a permission by its permission and view menu names.
+type PermissionPair struct {
+	Permission string
+	ViewMenu   string
+}
+
+// Permission represents a permission in the Superset application.
+type Permission struct {
+	ID             int64  `json:"id"`
+	PermissionName string `json:"permission_name"`
+	ViewMenuName   string `json:"view_menu_name"`
+}
+
+// Role represents a role in the Superset application.
+type Role struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// ViewMenu represents a view menu in the Superset application.
+type ViewMenu struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// QueryFilter narrows the SQL Lab query history returned by FetchQueries. Zero values match everything.
+type QueryFilter struct {
+	UserID        int64
+	DatabaseID    int64
+	Status        string
+	StartedAfter  time.Time
+	StartedBefore time.Time
+	Limit         int
+}
+
+// Query represents a SQL Lab query in the Superset application.
+type Query struct {
+	ID          int64   `json:"id"`
+	Status      string  `json:"status"`
+	SQL         string  `json:"sql"`
+	ExecutedSQL string  `json:"executed_sql"`
+	Schema      string  `json:"schema"`
+	TabName     string  `json:"tab_name"`
+	Rows        int64   `json:"rows"`
+	StartTime   float64 `json:"start_time"`
+	EndTime     float64 `json:"end_time"`
+	Database    struct {
+		DatabaseName string `json:"database_name"`
+	} `json:"database"`
+	User struct {
+		ID        int64  `json:"id"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+	} `json:"user"`
+}
+
+// Chart represents a chart (slice) in the Superset application.
+type Chart struct {
+	ID        int64  `json:"id"`
+	SliceName string `json:"slice_name"`
+	VizType   string `json:"viz_type"`
+}
+
+// ChartData represents the first query result returned by the chart data endpoint.
+type ChartData struct {
+	RowCount int64                    `json:"rowcount"`
+	ColNames []string                 `json:"colnames"`
+	Data     []map[string]interface{} `json:"data"`
+}
+
+// DashboardPermalink represents a permalink to a dashboard in the Superset application.
+type DashboardPermalink struct {
+	Key string `json:"key"`
+	URL string `json:"url"`
 }
@@ -0,0 +1,429 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// newTestClient activates httpmock and returns a client logged in to http://superset-host.
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	httpmock.Activate()
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	c, err := NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestSharedGetDetachedContext(t *testing.T) {
+	c := newTestClient(t)
+
+	// The roles are served slowly, so the second caller joins the request of the first
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+		func(req *http.Request) (*http.Response, error) {
+			<-release
+			return httpmock.NewStringResponse(200, `{"result": [{"id": 7, "name": "DWH-Analysts"}]}`), nil
+		})
+
+	short, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var shortErr, longErr error
+	var longID int64
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, shortErr = c.WithContext(short).GetRoleIDByName("DWH-Analysts")
+	}()
+	time.Sleep(10 * time.Millisecond)
+	go func() {
+		defer wg.Done()
+		longID, longErr = c.WithContext(context.Background()).GetRoleIDByName("DWH-Analysts")
+	}()
+
+	// The first caller gives up once its own deadline expires, without failing the second one
+	<-short.Done()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if !errors.Is(shortErr, context.DeadlineExceeded) {
+		t.Errorf("expected the first caller to time out, got %v", shortErr)
+	}
+	if longErr != nil || longID != 7 {
+		t.Errorf("expected the second caller to get role 7, got %d, %v", longID, longErr)
+	}
+	if calls := httpmock.GetCallCountInfo()["GET http://superset-host/api/v1/security/roles?q=(page_size:5000)"]; calls != 1 {
+		t.Errorf("expected the callers to share a single request, got %d", calls)
+	}
+}
+
+func TestDecodedGet(t *testing.T) {
+	c := newTestClient(t)
+
+	endpoint := "/api/v1/security/permissions-resources?q=(page:0,page_size:5000)"
+	httpmock.RegisterResponder("GET", "http://superset-host"+endpoint,
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("If-None-Match") == `"v1"` {
+				return httpmock.NewStringResponse(304, ""), nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"count": 1, "result": [{"id": 11, "permission": {"name": "can_read"}, "view_menu": {"name": "Dashboard"}}]}`)
+			resp.Header.Set("ETag", `"v1"`)
+			return resp, nil
+		})
+
+	for i := 0; i < 2; i++ {
+		resources, count, err := c.fetchPermissionResourcesPage(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 || len(resources) != 1 || resources[0].ID != 11 {
+			t.Errorf("request %d: expected permission 11, got %d of %v", i, count, resources)
+		}
+	}
+
+	// The page is served from the decoded cache without holding its raw body
+	if len(c.cache.etagCache) != 0 {
+		t.Errorf("expected no raw body to be cached, got %d", len(c.cache.etagCache))
+	}
+	if _, ok := c.cache.decodedCache[c.cacheKey(endpoint)]; !ok {
+		t.Error("expected the decoded page to be cached")
+	}
+	if calls := httpmock.GetCallCountInfo()["GET http://superset-host"+endpoint]; calls != 2 {
+		t.Errorf("expected the page to be revalidated, got %d requests", calls)
+	}
+}
+
+func TestFetchRoles(t *testing.T) {
+	searchEndpoint := "http://superset-host/api/v1/security/roles/search/?q=(page_size:5000)"
+
+	cases := map[string]struct {
+		responders      map[string]string
+		permissions     int
+		withPermissions bool
+	}{
+		"Search": {
+			responders: map[string]string{
+				searchEndpoint: `{"count": 1, "result": [{"id": 1, "name": "Admin", "permission_ids": [1, 2, 3]}]}`,
+			},
+			permissions:     3,
+			withPermissions: true,
+		},
+		"ListFallback": {
+			responders: map[string]string{
+				"http://superset-host/api/v1/security/roles?q=(page_size:5000)": `{"count": 1, "result": [{"id": 1, "name": "Admin"}]}`,
+			},
+		},
+		"MissingPermissions": {
+			responders: map[string]string{
+				searchEndpoint: `{"count": 1, "result": [{"id": 1, "name": "Admin"}]}`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t)
+			httpmock.RegisterResponder("GET", searchEndpoint, httpmock.NewStringResponder(404, `{"message": "Not found"}`))
+			for url, body := range tc.responders {
+				httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, body))
+			}
+
+			roles, _, withPermissions, err := c.FetchRoles()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(roles) != 1 || len(roles[0].Permissions) != tc.permissions || withPermissions != tc.withPermissions {
+				t.Errorf("expected %d permissions listed %t, got %+v listed %t", tc.permissions, tc.withPermissions, roles, withPermissions)
+			}
+			// The permissions of the roles are never fetched one role at a time
+			for call, count := range httpmock.GetCallCountInfo() {
+				if strings.HasSuffix(call, "/permissions/") && count > 0 {
+					t.Errorf("expected no request per role, got %d %s", count, call)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchRoleUsernames(t *testing.T) {
+	usersEndpoint := "http://superset-host/api/v1/security/users/?q=(columns:!(id,username,roles.id),page_size:5000)"
+
+	cases := map[string]struct {
+		status  int
+		body    string
+		users   map[int64][]string
+		wantErr bool
+	}{
+		"Users": {
+			status: 200,
+			body:   `{"result": [{"id": 1, "username": "admin", "roles": [{"id": 1}]}, {"id": 2, "username": "viewer", "roles": [{"id": 1}, {"id": 2}]}]}`,
+			users:  map[int64][]string{1: {"admin", "viewer"}, 2: {"viewer"}},
+		},
+		"MissingUserRoles": {
+			status:  200,
+			body:    `{"result": [{"id": 1, "username": "admin"}]}`,
+			wantErr: true,
+		},
+		"Forbidden": {
+			status:  403,
+			body:    `{"message": "Forbidden"}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t)
+			httpmock.RegisterResponder("GET", usersEndpoint, httpmock.NewStringResponder(tc.status, tc.body))
+
+			users, err := c.FetchRoleUsernames()
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected an error instead of empty users, got %v", users)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(users, tc.users) {
+				t.Errorf("expected %v, got %v", tc.users, users)
+			}
+		})
+	}
+}
+
+func TestDoConditionalGet(t *testing.T) {
+	endpoint := "/api/v1/database/1"
+
+	type response struct {
+		status int
+		etag   string
+		body   string
+	}
+	cases := map[string]struct {
+		responses []response
+		// ifNoneMatch is the If-None-Match header expected on the second request.
+		ifNoneMatch string
+		body        string
+		cached      bool
+	}{
+		"NotModified": {
+			responses:   []response{{200, `"v1"`, `{"id": 1}`}, {304, "", ""}},
+			ifNoneMatch: `"v1"`,
+			body:        `{"id": 1}`,
+			cached:      true,
+		},
+		"Changed": {
+			responses:   []response{{200, `"v1"`, `{"id": 1}`}, {200, `"v2"`, `{"id": 2}`}},
+			ifNoneMatch: `"v1"`,
+			body:        `{"id": 2}`,
+			cached:      true,
+		},
+		"WithoutETag": {
+			responses: []response{{200, "", `{"id": 1}`}, {200, "", `{"id": 2}`}},
+			body:      `{"id": 2}`,
+		},
+		"ErrorNotCached": {
+			responses: []response{{500, `"v1"`, `{"message": "error"}`}, {200, "", `{"id": 2}`}},
+			body:      `{"id": 2}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t)
+
+			var seen []string
+			httpmock.RegisterResponder("GET", "http://superset-host"+endpoint,
+				func(req *http.Request) (*http.Response, error) {
+					seen = append(seen, req.Header.Get("If-None-Match"))
+					next := tc.responses[len(seen)-1]
+					resp := httpmock.NewStringResponse(next.status, next.body)
+					if next.etag != "" {
+						resp.Header.Set("ETag", next.etag)
+					}
+					return resp, nil
+				})
+
+			var body []byte
+			for range tc.responses {
+				resp, err := c.DoRequest("GET", endpoint, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, _ = io.ReadAll(resp.Body)
+				resp.Body.Close()
+			}
+
+			if seen[0] != "" || seen[1] != tc.ifNoneMatch {
+				t.Errorf("expected If-None-Match %q on the second request only, got %q", tc.ifNoneMatch, seen)
+			}
+			if string(body) != tc.body {
+				t.Errorf("expected %s, got %s", tc.body, body)
+			}
+			if _, ok := c.cache.etagCache[c.cacheKey(endpoint)]; ok != tc.cached {
+				t.Errorf("expected cached %t, got %t", tc.cached, ok)
+			}
+		})
+	}
+}
+
+func TestDecompressResponse(t *testing.T) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte(`{"id": 1}`))
+	gz.Close()
+
+	cases := map[string]struct {
+		encoding string
+		body     []byte
+		expected string
+		wantErr  bool
+	}{
+		"Gzip":     {encoding: "gzip", body: compressed.Bytes(), expected: `{"id": 1}`},
+		"Identity": {body: []byte(`{"id": 1}`), expected: `{"id": 1}`},
+		"Invalid":  {encoding: "gzip", body: []byte(`{"id": 1}`), wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := httpmock.NewBytesResponse(200, tc.body)
+			if tc.encoding != "" {
+				resp.Header.Set("Content-Encoding", tc.encoding)
+			}
+
+			resp, err := decompressResponse(resp)
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected an error for an invalid gzip body")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tc.expected || resp.Header.Get("Content-Encoding") != "" {
+				t.Errorf("expected %s without Content-Encoding, got %s with %q", tc.expected, body, resp.Header.Get("Content-Encoding"))
+			}
+		})
+	}
+}
+
+func TestDisableCache(t *testing.T) {
+	databaseEndpoint := "/api/v1/database/1"
+	pageEndpoint := "/api/v1/security/permissions-resources?q=(page:0,page_size:5000)"
+
+	cases := map[string]struct {
+		disableCache bool
+		revalidated  bool
+	}{
+		"Enabled":  {revalidated: true},
+		"Disabled": {disableCache: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t)
+			c.DisableCache = tc.disableCache
+
+			var revalidations int
+			respond := func(body string) httpmock.Responder {
+				return func(req *http.Request) (*http.Response, error) {
+					if req.Header.Get("If-None-Match") != "" {
+						revalidations++
+					}
+					resp := httpmock.NewStringResponse(200, body)
+					resp.Header.Set("ETag", `"v1"`)
+					return resp, nil
+				}
+			}
+			httpmock.RegisterResponder("GET", "http://superset-host"+databaseEndpoint, respond(`{"id": 1}`))
+			httpmock.RegisterResponder("GET", "http://superset-host"+pageEndpoint,
+				respond(`{"count": 1, "result": [{"id": 11, "permission": {"name": "can_read"}, "view_menu": {"name": "Dashboard"}}]}`))
+
+			for i := 0; i < 2; i++ {
+				resp, err := c.DoRequest("GET", databaseEndpoint, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+				if _, _, err := c.fetchPermissionResourcesPage(0); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if revalidated := revalidations == 2; revalidated != tc.revalidated {
+				t.Errorf("expected revalidated %t, got %d conditional requests", tc.revalidated, revalidations)
+			}
+			if cached := len(c.cache.etagCache) > 0 || len(c.cache.decodedCache) > 0; cached != tc.revalidated {
+				t.Errorf("expected cached %t, got %d ETag and %d decoded entries", tc.revalidated, len(c.cache.etagCache), len(c.cache.decodedCache))
+			}
+		})
+	}
+}
+
+func TestBatchDelete(t *testing.T) {
+	cases := map[string]struct {
+		ids      []int64
+		status   int
+		requests int
+		notFound bool
+		wantErr  bool
+	}{
+		"OK":        {ids: []int64{3, 5}, status: 200, requests: 1},
+		"NoContent": {ids: []int64{3, 5}, status: 204, requests: 1},
+		"NotFound":  {ids: []int64{3, 5}, status: 404, requests: 1, notFound: true, wantErr: true},
+		"Rejected":  {ids: []int64{3, 5}, status: 422, requests: 1, wantErr: true},
+		"Empty":     {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t)
+			httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+				httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+			httpmock.RegisterResponder("DELETE", "http://superset-host/api/v1/chart/?q=!(3,5)",
+				func(req *http.Request) (*http.Response, error) {
+					if req.Header.Get("X-CSRFToken") != "fake-csrf-token" {
+						return httpmock.NewStringResponse(400, `{"message": "missing CSRF token"}`), nil
+					}
+					return httpmock.NewStringResponse(tc.status, `{"message": "Deleted 2 charts"}`), nil
+				})
+
+			err := c.BatchDeleteCharts(tc.ids)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %t, got %v", tc.wantErr, err)
+			}
+			if errors.Is(err, ErrNotFound) != tc.notFound {
+				t.Errorf("expected ErrNotFound %t, got %v", tc.notFound, err)
+			}
+			if calls := httpmock.GetCallCountInfo()["DELETE http://superset-host/api/v1/chart/?q=!(3,5)"]; calls != tc.requests {
+				t.Errorf("expected %d bulk requests, got %d", tc.requests, calls)
+			}
+		})
+	}
+}
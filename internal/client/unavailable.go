@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrServiceUnavailable means Superset kept answering with an HTML error page instead of its JSON API,
+// as it does while it is down for maintenance or its gateway cannot reach it.
+var ErrServiceUnavailable = errors.New("service unavailable, Superset may be down for maintenance")
+
+// ErrOutcomeUnknown means a request creating an object failed with a gateway error, which does not tell
+// whether Superset created the object, e.g. when the gateway timed out waiting for the response.
+var ErrOutcomeUnknown = errors.New("outcome unknown, Superset may have applied the request")
+
+const (
+	// defaultUnavailableRetryAttempts is the number of times a request is retried while Superset is unavailable.
+	defaultUnavailableRetryAttempts = 4
+	// defaultUnavailableRetryDelay is the delay before the first retry, doubled before each of the next ones.
+	defaultUnavailableRetryDelay = 5 * time.Second
+)
+
+// createRequestKey marks the context of the requests that create an object.
+type createRequestKey struct{}
+
+// forCreate returns a copy of the client whose requests create objects. A gateway error on such a request
+// does not tell whether the object was created, so the request is not retried and fails with
+// ErrOutcomeUnknown for the caller to look the object up before sending it again.
+func (c *Client) forCreate() *Client {
+	bound := *c
+	bound.ctx = context.WithValue(c.context(), createRequestKey{}, true)
+	return &bound
+}
+
+// unavailableTransport retries the requests Superset answers with an HTML 502, 503 or 504, backing off
+// between attempts, and fails with ErrServiceUnavailable once the retries are exhausted. Such pages
+// are served by the gateway in front of Superset, and would otherwise surface as JSON decoding errors.
+// Creates are only retried on a 503, see forCreate.
+type unavailableTransport struct {
+	attempts int
+	delay    time.Duration
+	next     http.RoundTripper
+}
+
+// RoundTrip sends the request, retrying it while Superset is unavailable.
+func (t *unavailableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	delay := t.delay
+	for attempt := 0; ; attempt++ {
+		resp, err := next.RoundTrip(req)
+		if err != nil || !isUnavailable(resp) {
+			return resp, err
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		// A maintenance page means the request never reached Superset, unlike a gateway that gave up waiting.
+		if req.Method != http.MethodGet && req.Context().Value(createRequestKey{}) != nil && resp.StatusCode != http.StatusServiceUnavailable {
+			return nil, fmt.Errorf("%w (status code: %d)", ErrOutcomeUnknown, resp.StatusCode)
+		}
+
+		if attempt >= t.attempts || (req.Body != nil && req.GetBody == nil) {
+			return nil, fmt.Errorf("%w (status code: %d)", ErrServiceUnavailable, resp.StatusCode)
+		}
+
+		wait := delay
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			wait = time.Duration(seconds) * time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		delay *= 2
+
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			retry.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+		req = retry
+	}
+}
+
+// isUnavailable reports whether the response is a gateway or maintenance error page rather than a
+// Superset API error, which is always JSON.
+func isUnavailable(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType != "application/json"
+}
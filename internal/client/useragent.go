@@ -0,0 +1,21 @@
+package client
+
+import "net/http"
+
+// userAgentTransport sets the User-Agent header of every request before handing it to the next transport.
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+// RoundTrip sends a copy of the request carrying the User-Agent header.
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return next.RoundTrip(req)
+}
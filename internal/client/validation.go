@@ -0,0 +1,86 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ValidationError is returned when Superset rejects a payload with field errors, which its REST API
+// reports as {"message": {"field": ["error", ...]}}.
+type ValidationError struct {
+	// Action describes the rejected request, e.g. "create database".
+	Action     string
+	StatusCode int
+	// Fields maps the payload fields Superset rejected to its error messages.
+	Fields map[string][]string
+	// RequestID is the X-Request-Id of the response, when Superset or its gateway set one.
+	RequestID string
+	// ServerTiming is the Server-Timing header of the response, when Superset or its gateway set one.
+	ServerTiming string
+}
+
+// Error lists the rejected fields in alphabetical order.
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		parts = append(parts, fmt.Sprintf("%s: %s", field, strings.Join(e.Fields[field], " ")))
+	}
+	return fmt.Sprintf("failed to %s, status code: %d, invalid fields: %s", e.Action, e.StatusCode, strings.Join(parts, "; ")) +
+		responseSuffix(e.RequestID, e.ServerTiming)
+}
+
+// responseSuffix returns the text appended to errors to identify the request in the server logs, and
+// to tell a slow backend from a slow gateway.
+func responseSuffix(requestID, serverTiming string) string {
+	var parts []string
+	if requestID != "" {
+		parts = append(parts, "request ID: "+requestID)
+	}
+	if serverTiming != "" {
+		parts = append(parts, "server timing: "+serverTiming)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// responseError builds the error of a rejected request from the response and its body. It returns a
+// *ValidationError when the body holds field errors, and embeds the scrubbed body otherwise. Either
+// ends with the request ID and server timing of the response, if any.
+func responseError(action string, resp *http.Response, body []byte) error {
+	requestID, serverTiming := resp.Header.Get("X-Request-Id"), resp.Header.Get("Server-Timing")
+	var result struct {
+		Message map[string]json.RawMessage `json:"message"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || len(result.Message) == 0 {
+		return fmt.Errorf("failed to %s, status code: %d, response: %s%s", action, resp.StatusCode, Scrub(string(body)), responseSuffix(requestID, serverTiming))
+	}
+
+	fields := make(map[string][]string, len(result.Message))
+	for field, raw := range result.Message {
+		var messages []string
+		if json.Unmarshal(raw, &messages) != nil {
+			// A single message, or the nested errors of an object field, e.g. extra.
+			var message string
+			if json.Unmarshal(raw, &message) != nil {
+				message = string(raw)
+			}
+			messages = []string{message}
+		}
+		for i, message := range messages {
+			messages[i] = Scrub(message)
+		}
+		fields[field] = messages
+	}
+	return &ValidationError{Action: action, StatusCode: resp.StatusCode, Fields: fields, RequestID: requestID, ServerTiming: serverTiming}
+}
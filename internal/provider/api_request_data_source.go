@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &apiRequestDataSource{}
+	_ datasource.DataSourceWithConfigure = &apiRequestDataSource{}
+)
+
+// NewAPIRequestDataSource is a helper function to simplify the provider implementation.
+func NewAPIRequestDataSource() datasource.DataSource {
+	return &apiRequestDataSource{}
+}
+
+// apiRequestDataSource is the data source implementation.
+type apiRequestDataSource struct {
+	client *client.Client
+}
+
+// apiRequestDataSourceModel maps the data source schema data.
+type apiRequestDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Path         types.String `tfsdk:"path"`
+	ResponseBody types.String `tfsdk:"response_body"`
+}
+
+// Metadata returns the data source type name.
+func (d *apiRequestDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_request"
+}
+
+// Schema defines the schema for the data source.
+func (d *apiRequestDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends a GET request to any endpoint of the Superset API, as an escape hatch for endpoints the provider does not model yet. " +
+			"Decode the response with jsondecode. Fails when the endpoint answers with a status outside 2xx.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the data source, the requested path.",
+				Computed:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the endpoint, starting with /api/, including the query string if any, e.g. /api/v1/tag/?q=(page_size:100). " +
+					"Use provider::superset::rison and urlencode to build the q parameter.",
+				Required: true,
+			},
+			"response_body": schema.StringAttribute{
+				MarkdownDescription: "Raw JSON body of the response.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *apiRequestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state apiRequestDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint := state.Path.ValueString()
+	validateAPIPath(path.Root("path"), endpoint, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, err := d.client.WithContext(ctx).APIRequest("GET", endpoint, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Superset API",
+			fmt.Sprintf("GET %s failed: %s", endpoint, err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(endpoint)
+	state.ResponseBody = types.StringValue(string(body))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *apiRequestDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
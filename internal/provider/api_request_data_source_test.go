@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccAPIRequestDataSource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for an endpoint the provider does not model
+	httpmock.RegisterResponder("GET", `=~^http://superset-host/api/v1/tag/\?q=`,
+		httpmock.NewStringResponder(200, `{"count": 1, "result": [{"id": 4, "name": "finance"}]}`))
+
+	// Mock the Superset API response for a forbidden endpoint
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/log/",
+		httpmock.NewStringResponder(403, `{"message": "Forbidden"}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The raw response is exposed for jsondecode
+			{
+				Config: providerConfig + `
+data "superset_api_request" "tags" {
+  path = "/api/v1/tag/?q=(page_size:100)"
+}
+
+output "tag_name" {
+  value = jsondecode(data.superset_api_request.tags.response_body).result[0].name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_api_request.tags", "id", "/api/v1/tag/?q=(page_size:100)"),
+					resource.TestCheckOutput("tag_name", "finance"),
+				),
+			},
+			// A status outside 2xx fails
+			{
+				Config: providerConfig + `
+data "superset_api_request" "logs" {
+  path = "/api/v1/log/"
+}
+`,
+				ExpectError: regexp.MustCompile(`status code: 403`),
+			},
+			// Only API paths are accepted
+			{
+				Config: providerConfig + `
+data "superset_api_request" "external" {
+  path = "https://example.com/api/v1/tag/"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid API Path`),
+			},
+		},
+	})
+}
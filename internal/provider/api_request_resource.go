@@ -0,0 +1,398 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &apiRequestResource{}
+	_ resource.ResourceWithConfigure      = &apiRequestResource{}
+	_ resource.ResourceWithValidateConfig = &apiRequestResource{}
+	_ resource.ResourceWithModifyPlan     = &apiRequestResource{}
+)
+
+// apiRequestIDPlaceholder is replaced by the object ID in read_path and delete_path.
+const apiRequestIDPlaceholder = "{id}"
+
+// NewAPIRequestResource is a helper function to simplify the provider implementation.
+func NewAPIRequestResource() resource.Resource {
+	return &apiRequestResource{}
+}
+
+// apiRequestResource is the resource implementation.
+type apiRequestResource struct {
+	client *client.Client
+}
+
+// apiRequestResourceModel maps the resource schema data.
+type apiRequestResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Path         types.String `tfsdk:"path"`
+	Method       types.String `tfsdk:"method"`
+	RequestBody  types.String `tfsdk:"request_body"`
+	ReadPath     types.String `tfsdk:"read_path"`
+	DeletePath   types.String `tfsdk:"delete_path"`
+	ObjectID     types.String `tfsdk:"object_id"`
+	ResponseBody types.String `tfsdk:"response_body"`
+}
+
+// Metadata returns the resource type name.
+func (r *apiRequestResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_request"
+}
+
+// Schema defines the schema for the resource.
+func (r *apiRequestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends a JSON payload to any endpoint of the Superset API, as an escape hatch for objects the provider does not model yet. " +
+			"The request must be idempotent, since it is sent again whenever path, method or request_body change: " +
+			"a PUT is sent again in place, and any other change replaces the resource. " +
+			"An optional read_path detects the object's deletion outside Terraform, and an optional delete_path removes it on destroy; " +
+			"without delete_path, destroying the resource leaves Superset untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the request, the object_id when the response has one and the path otherwise.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the endpoint the payload is sent to, starting with /api/, e.g. /api/v1/tag/.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"method": schema.StringAttribute{
+				MarkdownDescription: "HTTP method of the request, POST or PUT. Defaults to POST.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(http.MethodPost),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"request_body": schema.StringAttribute{
+				MarkdownDescription: "JSON payload of the request, e.g. built with jsonencode. A change is sent again in place when method is PUT, and replaces the resource otherwise.",
+				Required:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessPut,
+						"A change of the payload replaces the resource, unless method is PUT.",
+						"A change of the payload replaces the resource, unless method is PUT.",
+					),
+				},
+			},
+			"read_path": schema.StringAttribute{
+				MarkdownDescription: "Path read on refresh, starting with /api/. {id} is replaced by the object_id, e.g. /api/v1/tag/{id}. " +
+					"When it answers 404, the object is considered deleted and is sent again on the next apply.",
+				Optional: true,
+			},
+			"delete_path": schema.StringAttribute{
+				MarkdownDescription: "Path a DELETE request is sent to on destroy, starting with /api/. {id} is replaced by the object_id, e.g. /api/v1/tag/{id}.",
+				Optional:            true,
+			},
+			"object_id": schema.StringAttribute{
+				MarkdownDescription: "Value of the top-level id field of the response, if any.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"response_body": schema.StringAttribute{
+				MarkdownDescription: "Raw JSON body of the last response, from read_path when set and from the request otherwise.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// requiresReplaceUnlessPut replaces the resource when request_body changes, unless the request is a PUT:
+// replacing a PUT would first delete the object it updates through delete_path.
+func requiresReplaceUnlessPut(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var method types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("method"), &method)...)
+	resp.RequiresReplace = method.ValueString() != http.MethodPut
+}
+
+// ModifyPlan marks response_body unknown when a PUT is sent again in place, since Update replaces it
+// with the new response.
+func (r *apiRequestResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state apiRequestResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Method.ValueString() == http.MethodPut && !plan.RequestBody.Equal(state.RequestBody) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("response_body"), types.StringUnknown())...)
+	}
+}
+
+// ValidateConfig checks the method, the paths and the payload before anything is sent.
+func (r *apiRequestResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config apiRequestResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Method.IsNull() && !config.Method.IsUnknown() {
+		if method := config.Method.ValueString(); method != http.MethodPost && method != http.MethodPut {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("method"),
+				"Invalid Method",
+				fmt.Sprintf("method must be POST or PUT, got %q.", method),
+			)
+		}
+	}
+
+	for _, attribute := range []struct {
+		name  string
+		value types.String
+	}{
+		{"path", config.Path},
+		{"read_path", config.ReadPath},
+		{"delete_path", config.DeletePath},
+	} {
+		if !attribute.value.IsNull() && !attribute.value.IsUnknown() {
+			validateAPIPath(path.Root(attribute.name), attribute.value.ValueString(), &resp.Diagnostics)
+		}
+	}
+
+	if !config.RequestBody.IsNull() && !config.RequestBody.IsUnknown() && !json.Valid([]byte(config.RequestBody.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("request_body"),
+			"Invalid Request Body",
+			"request_body must be valid JSON, e.g. built with jsonencode.",
+		)
+	}
+}
+
+// validateAPIPath requires a path of the Superset API, so a full URL or a typo cannot send the
+// provider's credentials to another host or page.
+func validateAPIPath(attribute path.Path, value string, diags *diag.Diagnostics) {
+	if !strings.HasPrefix(value, "/api/") {
+		diags.AddAttributeError(
+			attribute,
+			"Invalid API Path",
+			fmt.Sprintf("The path must start with /api/, e.g. /api/v1/tag/, got %q.", value),
+		)
+	}
+}
+
+// Create sends the request and sets the initial Terraform state.
+func (r *apiRequestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_api_request", &resp.Diagnostics) {
+		return
+	}
+
+	var plan apiRequestResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	method, endpoint := plan.Method.ValueString(), plan.Path.ValueString()
+	body, err := r.client.WithContext(ctx).APIRequest(method, endpoint, json.RawMessage(plan.RequestBody.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Send Superset API Request",
+			fmt.Sprintf("%s %s failed: %s", method, endpoint, err),
+		)
+		return
+	}
+
+	objectID := responseObjectID(body)
+	if objectID == "" && (strings.Contains(plan.ReadPath.ValueString(), apiRequestIDPlaceholder) || strings.Contains(plan.DeletePath.ValueString(), apiRequestIDPlaceholder)) {
+		resp.Diagnostics.AddError(
+			"Missing Object ID",
+			fmt.Sprintf("%s %s succeeded, but its response has no top-level id to replace {id} in read_path or delete_path. "+
+				"The object it created may have to be removed in Superset. Response: %s", method, endpoint, client.Scrub(string(body))),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(endpoint)
+	plan.ObjectID = types.StringNull()
+	if objectID != "" {
+		plan.ID = types.StringValue(objectID)
+		plan.ObjectID = types.StringValue(objectID)
+	}
+	plan.ResponseBody = types.StringValue(string(body))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, fmt.Sprintf("Sent %s %s", method, endpoint))
+}
+
+// responseObjectID returns the top-level id field of a JSON response, or "" if it has none.
+func responseObjectID(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var response map[string]interface{}
+	if err := decoder.Decode(&response); err != nil {
+		return ""
+	}
+
+	switch id := response["id"].(type) {
+	case json.Number:
+		return id.String()
+	case string:
+		return id
+	default:
+		return ""
+	}
+}
+
+// expandAPIPath replaces {id} in the path with the object ID.
+func expandAPIPath(apiPath string, objectID types.String) string {
+	return strings.ReplaceAll(apiPath, apiRequestIDPlaceholder, url.PathEscape(objectID.ValueString()))
+}
+
+// Read refreshes the response from read_path, and keeps the state as is without it.
+func (r *apiRequestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Starting Read method")
+	var state apiRequestResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.ReadPath.IsNull() {
+		endpoint := expandAPIPath(state.ReadPath.ValueString(), state.ObjectID)
+		body, err := r.client.WithContext(ctx).APIRequest(http.MethodGet, endpoint, nil)
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			tflog.Debug(ctx, fmt.Sprintf("%s not found, removing from state", endpoint))
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Superset API",
+				fmt.Sprintf("GET %s failed: %s", endpoint, err),
+			)
+			return
+		}
+		state.ResponseBody = types.StringValue(string(body))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update sends a PUT again when its request_body changed. Otherwise it only stores the plan: read_path
+// and delete_path are used by later refreshes and the destroy, and every other change replaces the
+// resource, which sends the request again.
+func (r *apiRequestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Starting Update method")
+	var plan, state apiRequestResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Method.ValueString() == http.MethodPut && !plan.RequestBody.Equal(state.RequestBody) {
+		if refuseInReadOnlyMode(r.client, "update", "superset_api_request", &resp.Diagnostics) {
+			return
+		}
+
+		endpoint := plan.Path.ValueString()
+		body, err := r.client.WithContext(ctx).APIRequest(http.MethodPut, endpoint, json.RawMessage(plan.RequestBody.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Send Superset API Request",
+				fmt.Sprintf("PUT %s failed: %s", endpoint, err),
+			)
+			return
+		}
+		plan.ResponseBody = types.StringValue(string(body))
+		tflog.Debug(ctx, fmt.Sprintf("Sent PUT %s again", endpoint))
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete sends a DELETE request to delete_path, if set, and removes the resource from the Terraform state.
+func (r *apiRequestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Starting Delete method")
+	var state apiRequestResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.DeletePath.IsNull() {
+		if refuseInReadOnlyMode(r.client, "delete", "superset_api_request", &resp.Diagnostics) {
+			return
+		}
+
+		endpoint := expandAPIPath(state.DeletePath.ValueString(), state.ObjectID)
+		_, err := r.client.WithContext(ctx).APIRequest(http.MethodDelete, endpoint, nil)
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddError(
+				"Unable to Delete Superset API Object",
+				fmt.Sprintf("DELETE %s failed: %s", endpoint, err),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *apiRequestResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccAPIRequestResource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for the CSRF token
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+
+	// Mock the Superset API tag endpoints, creating a new tag ID on every POST
+	tags := map[int]string{}
+	nextID := 4
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/tag/",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-CSRFToken") != "fake-csrf-token" {
+				return httpmock.NewStringResponse(400, `{"message": "missing CSRF token"}`), nil
+			}
+			var payload struct {
+				Name string `json:"name"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				return httpmock.NewStringResponse(400, `{"message": "invalid payload"}`), nil
+			}
+			id := nextID
+			nextID++
+			tags[id] = payload.Name
+			return httpmock.NewStringResponse(201, fmt.Sprintf(`{"id": %d, "result": {"name": %q}}`, id, payload.Name)), nil
+		})
+	httpmock.RegisterResponder("GET", `=~^http://superset-host/api/v1/tag/(\d+)\z`,
+		func(req *http.Request) (*http.Response, error) {
+			id, _ := httpmock.GetSubmatchAsInt(req, 1)
+			name, ok := tags[int(id)]
+			if !ok {
+				return httpmock.NewStringResponse(404, `{"message": "Not found"}`), nil
+			}
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"id": %d, "result": {"name": %q}}`, id, name)), nil
+		})
+	httpmock.RegisterResponder("DELETE", `=~^http://superset-host/api/v1/tag/(\d+)\z`,
+		func(req *http.Request) (*http.Response, error) {
+			id, _ := httpmock.GetSubmatchAsInt(req, 1)
+			delete(tags, int(id))
+			return httpmock.NewStringResponse(200, `{"message": "OK"}`), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create posts the payload and keeps the ID of the created object
+			{
+				Config: providerConfig + testAccAPIRequestResourceConfig("finance"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_api_request.tag", "id", "4"),
+					resource.TestCheckResourceAttr("superset_api_request.tag", "object_id", "4"),
+					resource.TestCheckResourceAttr("superset_api_request.tag", "method", "POST"),
+					resource.TestCheckResourceAttr("superset_api_request.tag", "response_body", `{"id": 4, "result": {"name": "finance"}}`),
+				),
+			},
+			// Changing the payload deletes the object and posts it again
+			{
+				Config: providerConfig + testAccAPIRequestResourceConfig("payments"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_api_request.tag", "object_id", "5"),
+					func(_ *terraform.State) error {
+						if _, ok := tags[4]; ok || tags[5] != "payments" {
+							return fmt.Errorf("expected tag 4 to be replaced by tag 5, got %v", tags)
+						}
+						return nil
+					},
+				),
+			},
+			// An object deleted outside Terraform is posted again
+			{
+				PreConfig: func() { delete(tags, 5) },
+				Config:    providerConfig + testAccAPIRequestResourceConfig("payments"),
+				Check:     resource.TestCheckResourceAttr("superset_api_request.tag", "object_id", "6"),
+			},
+			// The payload must be JSON
+			{
+				Config: providerConfig + `
+resource "superset_api_request" "invalid" {
+  path         = "/api/v1/tag/"
+  request_body = "name=finance"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Request Body`),
+			},
+			// Only POST and PUT are sent
+			{
+				Config: providerConfig + `
+resource "superset_api_request" "invalid" {
+  path         = "/api/v1/tag/"
+  method       = "PATCH"
+  request_body = "{}"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Method`),
+			},
+		},
+	})
+
+	if len(tags) != 0 {
+		t.Errorf("expected destroy to delete the tag, got %v", tags)
+	}
+}
+
+func testAccAPIRequestResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "superset_api_request" "tag" {
+  path         = "/api/v1/tag/"
+  request_body = jsonencode({ name = %q })
+  read_path    = "/api/v1/tag/{id}"
+  delete_path  = "/api/v1/tag/{id}"
+}
+`, name)
+}
+
+func TestResponseObjectID(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"Number", `{"id": 12345678901234, "result": {}}`, "12345678901234"},
+		{"String", `{"id": "a1b2", "result": {}}`, "a1b2"},
+		{"Missing", `{"result": {"id": 3}}`, ""},
+		{"Array", `[{"id": 3}]`, ""},
+		{"Empty", ``, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := responseObjectID([]byte(tt.body)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccAPIRequestResourcePut(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for the CSRF token
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+
+	// Mock an existing tag that is updated with PUT
+	name := "finance"
+	httpmock.RegisterResponder("PUT", "http://superset-host/api/v1/tag/4",
+		func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				Name string `json:"name"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				return httpmock.NewStringResponse(400, `{"message": "invalid payload"}`), nil
+			}
+			name = payload.Name
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"id": 4, "result": {"name": %q}}`, name)), nil
+		})
+	httpmock.RegisterResponder("DELETE", "http://superset-host/api/v1/tag/4",
+		httpmock.NewStringResponder(200, `{"message": "OK"}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccAPIRequestResourcePutConfig("finance"),
+				Check:  resource.TestCheckResourceAttr("superset_api_request.tag", "object_id", "4"),
+			},
+			// Changing the payload sends the PUT again without deleting the tag first
+			{
+				Config: providerConfig + testAccAPIRequestResourcePutConfig("payments"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_api_request.tag", "response_body", `{"id": 4, "result": {"name": "payments"}}`),
+					func(_ *terraform.State) error {
+						calls := httpmock.GetCallCountInfo()
+						if puts, deletes := calls["PUT http://superset-host/api/v1/tag/4"], calls["DELETE http://superset-host/api/v1/tag/4"]; puts != 2 || deletes != 0 || name != "payments" {
+							return fmt.Errorf("expected the tag to be updated in place, got %d PUTs, %d DELETEs and name %q", puts, deletes, name)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccAPIRequestResourcePutConfig(name string) string {
+	return fmt.Sprintf(`
+resource "superset_api_request" "tag" {
+  path         = "/api/v1/tag/4"
+  method       = "PUT"
+  request_body = jsonencode({ name = %q })
+  delete_path  = "/api/v1/tag/{id}"
+}
+`, name)
+}
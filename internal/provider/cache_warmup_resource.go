@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &cacheWarmupResource{}
+	_ resource.ResourceWithConfigure      = &cacheWarmupResource{}
+	_ resource.ResourceWithValidateConfig = &cacheWarmupResource{}
+)
+
+// NewCacheWarmupResource is a helper function to simplify the provider implementation.
+func NewCacheWarmupResource() resource.Resource {
+	return &cacheWarmupResource{}
+}
+
+// cacheWarmupResource is the resource implementation.
+type cacheWarmupResource struct {
+	client *client.Client
+}
+
+// cacheWarmupResourceModel maps the resource schema data.
+type cacheWarmupResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ChartIDs     types.Set    `tfsdk:"chart_ids"`
+	DashboardIDs types.Set    `tfsdk:"dashboard_ids"`
+	Triggers     types.Map    `tfsdk:"triggers"`
+	LastWarmed   types.String `tfsdk:"last_warmed"`
+}
+
+// Metadata returns the resource type name.
+func (r *cacheWarmupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cache_warmup"
+}
+
+// Schema defines the schema for the resource.
+func (r *cacheWarmupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Warms up the cache of Superset charts and dashboards, so they render from the cache on first view. " +
+			"The warmup runs when the resource is created and again whenever chart_ids, dashboard_ids or triggers change; " +
+			"destroying the resource leaves the cache untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the warmup, derived from the Superset host and the warmed chart and dashboard IDs.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"chart_ids": schema.SetAttribute{
+				MarkdownDescription: "Numeric identifiers of the charts to warm up.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"dashboard_ids": schema.SetAttribute{
+				MarkdownDescription: "Numeric identifiers of the dashboards to warm up. Every chart of a dashboard is warmed up with the dashboard's default filters.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that trigger a new warmup when changed, e.g. a deployment ID or a timestamp.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"last_warmed": schema.StringAttribute{
+				MarkdownDescription: "Timestamp of the last warmup.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig requires at least one chart or dashboard to warm up.
+func (r *cacheWarmupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config cacheWarmupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.ChartIDs.IsUnknown() || config.DashboardIDs.IsUnknown() {
+		return
+	}
+
+	if len(config.ChartIDs.Elements()) == 0 && len(config.DashboardIDs.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("chart_ids"),
+			"Nothing to Warm Up",
+			"At least one of chart_ids or dashboard_ids must list an ID.",
+		)
+	}
+}
+
+// Create warms up the cache of the charts and dashboards and sets the initial Terraform state.
+func (r *cacheWarmupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_cache_warmup", &resp.Diagnostics) {
+		return
+	}
+
+	var plan cacheWarmupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var chartIDs, dashboardIDs []int64
+	resp.Diagnostics.Append(plan.ChartIDs.ElementsAs(ctx, &chartIDs, false)...)
+	resp.Diagnostics.Append(plan.DashboardIDs.ElementsAs(ctx, &dashboardIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	warmUpCache(ctx, r.client.WithContext(ctx), chartIDs, dashboardIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Dashboard IDs are negated so that chart 3 and dashboard 3 yield different identifiers.
+	ids := append([]int64{}, chartIDs...)
+	for _, dashboardID := range dashboardIDs {
+		ids = append(ids, -dashboardID)
+	}
+	plan.ID = listDataSourceID(r.client.Host, "cache_warmup", ids)
+	plan.LastWarmed = types.StringValue(time.Now().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, fmt.Sprintf("Warmed up %d charts and %d dashboards", len(chartIDs), len(dashboardIDs)))
+}
+
+// warmUpCache warms up each chart, then each chart of each dashboard with the dashboard's filters.
+// It stops at the first failure, so a broken chart is reported instead of hidden by the others.
+func warmUpCache(ctx context.Context, supersetClient *client.Client, chartIDs, dashboardIDs []int64, diags *diag.Diagnostics) {
+	for _, chartID := range chartIDs {
+		if err := supersetClient.WarmUpChartCache(chartID, 0); err != nil {
+			diags.AddError(
+				"Unable to Warm Up Superset Chart Cache",
+				fmt.Sprintf("Could not warm up the cache of chart ID %d: %s", chartID, err),
+			)
+			return
+		}
+	}
+
+	for _, dashboardID := range dashboardIDs {
+		dashboardCharts, err := supersetClient.GetDashboardChartIDs(dashboardID)
+		if err != nil {
+			diags.AddError(
+				"Unable to Warm Up Superset Dashboard Cache",
+				fmt.Sprintf("Could not read the charts of dashboard ID %d: %s", dashboardID, err),
+			)
+			return
+		}
+		for _, chartID := range dashboardCharts {
+			if err := supersetClient.WarmUpChartCache(chartID, dashboardID); err != nil {
+				diags.AddError(
+					"Unable to Warm Up Superset Dashboard Cache",
+					fmt.Sprintf("Could not warm up the cache of chart ID %d on dashboard ID %d: %s", chartID, dashboardID, err),
+				)
+				return
+			}
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Warmed up %d charts of dashboard ID %d", len(dashboardCharts), dashboardID))
+	}
+}
+
+// Read keeps the state as is; the warmup has no remote object to refresh.
+func (r *cacheWarmupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Starting Read method")
+	var state cacheWarmupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only stores the plan, since every attribute change replaces the resource and warms up again.
+func (r *cacheWarmupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Starting Update method")
+	var plan cacheWarmupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state without changing the cache.
+func (r *cacheWarmupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Starting Delete method")
+	resp.State.RemoveResource(ctx)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cacheWarmupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
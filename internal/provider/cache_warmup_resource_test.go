@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccCacheWarmupResource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for the CSRF token
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+
+	// Mock the Superset API response for the charts of a dashboard
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/dashboard/5/charts",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 11}, {"id": 12}]}`))
+
+	// Mock the Superset API response for warming up a chart, recording the chart/dashboard pairs
+	warmed := map[string]int{}
+	httpmock.RegisterResponder("PUT", "http://superset-host/api/v1/chart/warm_up_cache",
+		func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				ChartID     int64 `json:"chart_id"`
+				DashboardID int64 `json:"dashboard_id"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				return httpmock.NewStringResponse(400, `{"message": "invalid payload"}`), nil
+			}
+			warmed[fmt.Sprintf("%d/%d", payload.ChartID, payload.DashboardID)]++
+			if payload.ChartID == 13 {
+				return httpmock.NewStringResponse(200, `{"result": [{"chart_id": 13, "viz_error": "relation \"payments\" does not exist", "viz_status": "failed"}]}`), nil
+			}
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"result": [{"chart_id": %d, "viz_error": null, "viz_status": "success"}]}`, payload.ChartID)), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create warms up the charts, and the charts of the dashboards with their filters
+			{
+				Config: providerConfig + testAccCacheWarmupResourceConfig("[3]", "v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_cache_warmup.test", "chart_ids.#", "1"),
+					resource.TestCheckResourceAttrSet("superset_cache_warmup.test", "id"),
+					resource.TestCheckResourceAttrSet("superset_cache_warmup.test", "last_warmed"),
+					func(_ *terraform.State) error {
+						for _, pair := range []string{"3/0", "11/5", "12/5"} {
+							if warmed[pair] != 1 {
+								return fmt.Errorf("expected chart/dashboard %s to be warmed up once, got %v", pair, warmed)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			// Changing a trigger warms up again
+			{
+				Config: providerConfig + testAccCacheWarmupResourceConfig("[3]", "v2"),
+				Check: func(_ *terraform.State) error {
+					if warmed["3/0"] != 2 || warmed["11/5"] != 2 {
+						return fmt.Errorf("expected a second warmup, got %v", warmed)
+					}
+					return nil
+				},
+			},
+			// A chart whose query fails is reported
+			{
+				Config:      providerConfig + testAccCacheWarmupResourceConfig("[13]", "v2"),
+				ExpectError: regexp.MustCompile(`relation "payments" does not exist`),
+			},
+			// Something must be warmed up
+			{
+				Config:      providerConfig + `resource "superset_cache_warmup" "empty" {}`,
+				ExpectError: regexp.MustCompile("Nothing to Warm Up"),
+			},
+		},
+	})
+}
+
+func testAccCacheWarmupResourceConfig(chartIDs, deployment string) string {
+	return fmt.Sprintf(`
+resource "superset_cache_warmup" "test" {
+  chart_ids     = %s
+  dashboard_ids = [5]
+
+  triggers = {
+    deployment = %q
+  }
+}
+`, chartIDs, deployment)
+}
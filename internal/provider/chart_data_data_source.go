@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// defaultChartDataRowLimit is the number of rows exposed when row_limit is not set.
+const defaultChartDataRowLimit = 10
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &chartDataDataSource{}
+	_ datasource.DataSourceWithConfigure = &chartDataDataSource{}
+)
+
+// NewChartDataDataSource is a helper function to simplify the provider implementation.
+func NewChartDataDataSource() datasource.DataSource {
+	return &chartDataDataSource{}
+}
+
+// chartDataDataSource is the data source implementation.
+type chartDataDataSource struct {
+	client *client.Client
+}
+
+// chartDataDataSourceModel maps the data source schema data.
+type chartDataDataSourceModel struct {
+	ChartID  types.Int64    `tfsdk:"chart_id"`
+	RowLimit types.Int64    `tfsdk:"row_limit"`
+	RowCount types.Int64    `tfsdk:"row_count"`
+	Columns  []types.String `tfsdk:"columns"`
+	Rows     []types.String `tfsdk:"rows"`
+}
+
+// Metadata returns the data source type name.
+func (d *chartDataDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_chart_data"
+}
+
+// Schema defines the schema for the data source.
+func (d *chartDataDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Executes the saved query of a chart and returns its row count and first rows. " +
+			"Useful in CI to check that provisioned datasets and charts actually return data.",
+		Attributes: map[string]schema.Attribute{
+			"chart_id": schema.Int64Attribute{
+				MarkdownDescription: "Numeric identifier of the chart to query.",
+				Required:            true,
+			},
+			"row_limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of rows exposed in `rows`. Defaults to %d.", defaultChartDataRowLimit),
+				Optional:            true,
+			},
+			"row_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of rows returned by the chart query.",
+				Computed:            true,
+			},
+			"columns": schema.ListAttribute{
+				MarkdownDescription: "Names of the columns returned by the chart query.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"rows": schema.ListAttribute{
+				MarkdownDescription: "First rows returned by the chart query, each encoded as a JSON object.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *chartDataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state chartDataDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rowLimit := int64(defaultChartDataRowLimit)
+	if !state.RowLimit.IsNull() {
+		rowLimit = state.RowLimit.ValueInt64()
+	}
+	if rowLimit < 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Row Limit",
+			fmt.Sprintf("row_limit must not be negative, got %d.", rowLimit),
+		)
+		return
+	}
+
+	data, err := d.client.WithContext(ctx).GetChartData(state.ChartID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Superset Chart Data",
+			err.Error(),
+		)
+		return
+	}
+
+	state.RowCount = types.Int64Value(data.RowCount)
+
+	state.Columns = []types.String{}
+	for _, column := range data.ColNames {
+		state.Columns = append(state.Columns, types.StringValue(column))
+	}
+
+	state.Rows = []types.String{}
+	for i, row := range data.Data {
+		if int64(i) >= rowLimit {
+			break
+		}
+		encoded, err := json.Marshal(row)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Encode Superset Chart Data",
+				fmt.Sprintf("Row %d of chart %d could not be encoded as JSON: %s", i, state.ChartID.ValueInt64(), err),
+			)
+			return
+		}
+		state.Rows = append(state.Rows, types.StringValue(string(encoded)))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *chartDataDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccChartDataDataSource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for fetching the CSRF token
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+
+	// Mock the Superset API response for fetching the chart query context
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/chart/7?q=(columns:!(id,query_context))",
+		httpmock.NewStringResponder(200, `{
+			"id": 7,
+			"result": {
+				"id": 7,
+				"query_context": "{\"datasource\":{\"id\":3,\"type\":\"table\"},\"queries\":[{\"columns\":[\"country\"],\"metrics\":[\"count\"]}],\"result_format\":\"json\",\"result_type\":\"full\"}"
+			}
+		}`))
+
+	// Mock the Superset API response for executing the chart query
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/chart/data",
+		httpmock.NewStringResponder(200, `{
+			"result": [
+				{
+					"rowcount": 3,
+					"colnames": ["country", "count"],
+					"data": [
+						{"country": "DE", "count": 10},
+						{"country": "FR", "count": 7},
+						{"country": "NL", "count": 2}
+					]
+				}
+			]
+		}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccChartDataDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_chart_data.test", "row_count", "3"),
+					resource.TestCheckResourceAttr("data.superset_chart_data.test", "columns.#", "2"),
+					resource.TestCheckResourceAttr("data.superset_chart_data.test", "columns.0", "country"),
+					resource.TestCheckResourceAttr("data.superset_chart_data.test", "rows.#", "2"),
+					resource.TestCheckResourceAttr("data.superset_chart_data.test", "rows.0", `{"count":10,"country":"DE"}`),
+					resource.TestCheckResourceAttr("data.superset_chart_data.test", "rows.1", `{"count":7,"country":"FR"}`),
+				),
+			},
+		},
+	})
+}
+
+const testAccChartDataDataSourceConfig = `
+data "superset_chart_data" "test" {
+  chart_id  = 7
+  row_limit = 2
+}
+`
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &currentUserDataSource{}
+	_ datasource.DataSourceWithConfigure = &currentUserDataSource{}
+)
+
+// NewCurrentUserDataSource is a helper function to simplify the provider implementation.
+func NewCurrentUserDataSource() datasource.DataSource {
+	return &currentUserDataSource{}
+}
+
+// currentUserDataSource is the data source implementation.
+type currentUserDataSource struct {
+	client *client.Client
+}
+
+// currentUserDataSourceModel maps the data source schema data.
+type currentUserDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	UserID    types.Int64  `tfsdk:"user_id"`
+	Username  types.String `tfsdk:"username"`
+	FirstName types.String `tfsdk:"first_name"`
+	LastName  types.String `tfsdk:"last_name"`
+	Email     types.String `tfsdk:"email"`
+	Active    types.Bool   `tfsdk:"active"`
+	Roles     []string     `tfsdk:"roles"`
+}
+
+// Metadata returns the data source type name.
+func (d *currentUserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user"
+}
+
+// Schema defines the schema for the data source.
+func (d *currentUserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the user the provider is authenticated as from `/api/v1/me/`, e.g. to assert the service account " +
+			"has the Admin role before managing roles, or to use it as the default owner of objects.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the data source, the numeric identifier of the user as a string.",
+				Computed:            true,
+			},
+			"user_id": schema.Int64Attribute{
+				MarkdownDescription: "Numeric identifier of the user, as expected by the `owners` of charts, dashboards and datasets.",
+				Computed:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the user.",
+				Computed:            true,
+			},
+			"first_name": schema.StringAttribute{
+				MarkdownDescription: "First name of the user.",
+				Computed:            true,
+			},
+			"last_name": schema.StringAttribute{
+				MarkdownDescription: "Last name of the user.",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the user.",
+				Computed:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is active.",
+				Computed:            true,
+			},
+			"roles": schema.ListAttribute{
+				MarkdownDescription: "Names of the roles of the user, sorted by name. " +
+					"Check `contains(data.superset_current_user.me.roles, \"Admin\")` in a precondition to fail early when the provider account cannot manage security.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *currentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	user, err := d.client.WithContext(ctx).GetCurrentUser()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Superset Current User",
+			err.Error(),
+		)
+		return
+	}
+
+	state := currentUserDataSourceModel{
+		ID:        types.StringValue(strconv.FormatInt(user.ID, 10)),
+		UserID:    types.Int64Value(user.ID),
+		Username:  types.StringValue(user.Username),
+		FirstName: types.StringValue(user.FirstName),
+		LastName:  types.StringValue(user.LastName),
+		Email:     types.StringValue(user.Email),
+		Active:    types.BoolValue(user.IsActive),
+		Roles:     user.Roles,
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *currentUserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
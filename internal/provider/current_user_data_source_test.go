@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccCurrentUserDataSource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API responses for the authenticated user and its roles
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/me/",
+		httpmock.NewStringResponder(200, `{
+			"result": {"id": 7, "username": "terraform", "first_name": "Terraform", "last_name": "Service", "email": "terraform@example.com", "is_active": true}
+		}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/me/roles/",
+		httpmock.NewStringResponder(200, `{
+			"result": {"roles": {"sql_lab": [["can_read", "SQLLab"]], "Admin": [["can_read", "Database"], ["can_write", "Role"]]}}
+		}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccCurrentUserDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_current_user.me", "id", "7"),
+					resource.TestCheckResourceAttr("data.superset_current_user.me", "user_id", "7"),
+					resource.TestCheckResourceAttr("data.superset_current_user.me", "username", "terraform"),
+					resource.TestCheckResourceAttr("data.superset_current_user.me", "first_name", "Terraform"),
+					resource.TestCheckResourceAttr("data.superset_current_user.me", "last_name", "Service"),
+					resource.TestCheckResourceAttr("data.superset_current_user.me", "email", "terraform@example.com"),
+					resource.TestCheckResourceAttr("data.superset_current_user.me", "active", "true"),
+					resource.TestCheckResourceAttr("data.superset_current_user.me", "roles.#", "2"),
+					resource.TestCheckResourceAttr("data.superset_current_user.me", "roles.0", "Admin"),
+					resource.TestCheckResourceAttr("data.superset_current_user.me", "roles.1", "sql_lab"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCurrentUserDataSourceConfig = `
+data "superset_current_user" "me" {}
+`
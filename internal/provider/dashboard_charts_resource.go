@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &dashboardChartsResource{}
+	_ resource.ResourceWithConfigure   = &dashboardChartsResource{}
+	_ resource.ResourceWithImportState = &dashboardChartsResource{}
+)
+
+// NewDashboardChartsResource is a helper function to simplify the provider implementation.
+func NewDashboardChartsResource() resource.Resource {
+	return &dashboardChartsResource{}
+}
+
+// dashboardChartsResource is the resource implementation.
+type dashboardChartsResource struct {
+	client *client.Client
+}
+
+// dashboardChartsResourceModel maps the resource schema data.
+type dashboardChartsResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	DashboardID types.Int64  `tfsdk:"dashboard_id"`
+	ChartIDs    types.Set    `tfsdk:"chart_ids"`
+}
+
+// Metadata returns the resource type name.
+func (r *dashboardChartsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_charts"
+}
+
+// Schema defines the schema for the resource.
+func (r *dashboardChartsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds charts to an existing Superset dashboard, without managing its layout. " +
+			"Only the listed charts are managed, so several modules can each contribute charts to a shared dashboard; " +
+			"charts added outside this resource are left on the dashboard. " +
+			"Superset can only replace the dashboards of a chart as a whole, so each chart is read, changed and written back under a lock " +
+			"held per chart by the provider: a change to the same chart made at the same time by another Terraform run or in the Superset UI can be lost.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the resource, the dashboard ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dashboard_id": schema.Int64Attribute{
+				MarkdownDescription: "Numeric identifier of the dashboard.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"chart_ids": schema.SetAttribute{
+				MarkdownDescription: "Numeric identifiers of the charts to show on the dashboard. " +
+					"Charts missing from the dashboard layout are placed at its bottom by Superset.",
+				ElementType: types.Int64Type,
+				Required:    true,
+			},
+		},
+	}
+}
+
+// Create adds the charts to the dashboard and sets the initial Terraform state.
+func (r *dashboardChartsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_dashboard_charts", &resp.Diagnostics) {
+		return
+	}
+
+	var plan dashboardChartsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyCharts(ctx, r.client.WithContext(ctx), nil, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(plan.DashboardID.ValueInt64(), 10))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, fmt.Sprintf("Added charts to dashboard ID %d", plan.DashboardID.ValueInt64()))
+}
+
+// Read refreshes which of the managed charts are still on the dashboard.
+func (r *dashboardChartsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Starting Read method")
+	var state dashboardChartsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dashboardID := state.DashboardID.ValueInt64()
+	remote, err := r.client.WithContext(ctx).GetDashboardChartIDs(dashboardID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Debug(ctx, fmt.Sprintf("Dashboard ID %d not found, removing from state", dashboardID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading dashboard charts",
+			fmt.Sprintf("Could not read the charts of dashboard ID %d: %s", dashboardID, err),
+		)
+		return
+	}
+
+	// Charts managed elsewhere are not reported, so they do not show up as drift. Right after
+	// an import nothing is managed yet, so every chart of the dashboard is adopted.
+	chartIDs := remote
+	if !state.ChartIDs.IsNull() {
+		var managed []int64
+		resp.Diagnostics.Append(state.ChartIDs.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		onDashboard := map[int64]bool{}
+		for _, id := range remote {
+			onDashboard[id] = true
+		}
+		chartIDs = []int64{}
+		for _, id := range managed {
+			if onDashboard[id] {
+				chartIDs = append(chartIDs, id)
+			}
+		}
+	}
+
+	state.ChartIDs, diags = types.SetValueFrom(ctx, types.Int64Type, chartIDs)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update adds and removes the changed charts and sets the updated Terraform state on success.
+func (r *dashboardChartsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Starting Update method")
+	if refuseInReadOnlyMode(r.client, "update", "superset_dashboard_charts", &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state dashboardChartsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyCharts(ctx, r.client.WithContext(ctx), &state, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the managed charts from the dashboard and removes the Terraform state on success.
+func (r *dashboardChartsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Starting Delete method")
+	if refuseInReadOnlyMode(r.client, "delete", "superset_dashboard_charts", &resp.Diagnostics) {
+		return
+	}
+
+	var state dashboardChartsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cleared := dashboardChartsResourceModel{
+		DashboardID: state.DashboardID,
+		ChartIDs:    types.SetValueMust(types.Int64Type, nil),
+	}
+	r.applyCharts(ctx, r.client.WithContext(ctx), &state, &cleared, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// applyCharts adds the dashboard to the planned charts, and removes it from the charts managed in
+// the prior state but no longer planned. Charts that no longer exist are skipped when removing.
+func (r *dashboardChartsResource) applyCharts(ctx context.Context, supersetClient *client.Client, prior, plan *dashboardChartsResourceModel, diags *diag.Diagnostics) {
+	dashboardID := plan.DashboardID.ValueInt64()
+
+	var planned, previous []int64
+	diags.Append(plan.ChartIDs.ElementsAs(ctx, &planned, false)...)
+	if prior != nil {
+		diags.Append(prior.ChartIDs.ElementsAs(ctx, &previous, false)...)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	keep := map[int64]bool{}
+	for _, chartID := range planned {
+		keep[chartID] = true
+		err := updateChartDashboards(supersetClient, chartID, dashboardID, true)
+		if err != nil {
+			diags.AddError(
+				"Error adding chart to dashboard",
+				fmt.Sprintf("Could not add chart ID %d to dashboard ID %d: %s", chartID, dashboardID, err),
+			)
+			return
+		}
+	}
+
+	for _, chartID := range previous {
+		if keep[chartID] {
+			continue
+		}
+		err := updateChartDashboards(supersetClient, chartID, dashboardID, false)
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			diags.AddError(
+				"Error removing chart from dashboard",
+				fmt.Sprintf("Could not remove chart ID %d from dashboard ID %d: %s", chartID, dashboardID, err),
+			)
+			return
+		}
+	}
+}
+
+// updateChartDashboards adds the dashboard to, or removes it from, the dashboards of a chart.
+// The chart is only updated when its dashboards actually change. Superset can only replace the
+// dashboards of a chart, so the update is locked per chart against the other resources of this
+// provider, but can still overwrite a concurrent change made outside it.
+func updateChartDashboards(supersetClient *client.Client, chartID, dashboardID int64, add bool) error {
+	defer supersetClient.LockChart(chartID)()
+
+	current, err := supersetClient.GetChartDashboardIDs(chartID)
+	if err != nil {
+		return err
+	}
+
+	var dashboards []int64
+	found := false
+	for _, id := range current {
+		if id == dashboardID {
+			found = true
+			if !add {
+				continue
+			}
+		}
+		dashboards = append(dashboards, id)
+	}
+	if found == add {
+		return nil
+	}
+	if add {
+		dashboards = append(dashboards, dashboardID)
+	}
+
+	return supersetClient.UpdateChartDashboards(chartID, dashboards)
+}
+
+// ImportState imports the charts of a dashboard by its ID.
+func (r *dashboardChartsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	dashboardID, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing dashboard ID", fmt.Sprintf("Could not parse dashboard ID '%s': %s", req.ID, err))
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dashboard_id"), dashboardID)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *dashboardChartsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
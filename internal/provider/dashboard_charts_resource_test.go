@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccDashboardChartsResource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for the CSRF token
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+
+	// The dashboards of each chart; chart 13 was added to dashboard 7 by another module
+	chartDashboards := map[int64][]int64{11: {}, 12: {3}, 13: {7}}
+	for chartID := range chartDashboards {
+		chartID := chartID
+		httpmock.RegisterResponder("GET", fmt.Sprintf("http://superset-host/api/v1/chart/%d?q=(columns:!(id,dashboards.id))", chartID),
+			func(req *http.Request) (*http.Response, error) {
+				dashboards := []map[string]int64{}
+				for _, id := range chartDashboards[chartID] {
+					dashboards = append(dashboards, map[string]int64{"id": id})
+				}
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"result": map[string]interface{}{"id": chartID, "dashboards": dashboards},
+				})
+			})
+		httpmock.RegisterResponder("PUT", fmt.Sprintf("http://superset-host/api/v1/chart/%d", chartID),
+			func(req *http.Request) (*http.Response, error) {
+				var payload struct {
+					Dashboards []int64 `json:"dashboards"`
+				}
+				if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+					return httpmock.NewStringResponse(400, err.Error()), nil
+				}
+				chartDashboards[chartID] = payload.Dashboards
+				return httpmock.NewStringResponse(200, `{"id": 1, "result": {}}`), nil
+			})
+	}
+
+	// The charts of dashboard 7 follow the dashboards of each chart
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/dashboard/7/charts",
+		func(req *http.Request) (*http.Response, error) {
+			charts := []map[string]int64{}
+			for chartID, dashboards := range chartDashboards {
+				for _, id := range dashboards {
+					if id == 7 {
+						charts = append(charts, map[string]int64{"id": chartID})
+					}
+				}
+			}
+			sort.Slice(charts, func(i, j int) bool { return charts[i]["id"] < charts[j]["id"] })
+			return httpmock.NewJsonResponse(200, map[string]interface{}{"result": charts})
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccDashboardChartsResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_dashboard_charts.sales", "id", "7"),
+					resource.TestCheckResourceAttr("superset_dashboard_charts.sales", "chart_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr("superset_dashboard_charts.sales", "chart_ids.*", "11"),
+					resource.TestCheckTypeSetElemAttr("superset_dashboard_charts.sales", "chart_ids.*", "12"),
+				),
+			},
+		},
+	})
+
+	// Destroy removes only the managed charts from the dashboard
+	if len(chartDashboards[11]) != 0 {
+		t.Errorf("expected chart 11 to be removed from dashboard 7, got %v", chartDashboards[11])
+	}
+	if len(chartDashboards[12]) != 1 || chartDashboards[12][0] != 3 {
+		t.Errorf("expected chart 12 to stay on dashboard 3 only, got %v", chartDashboards[12])
+	}
+	if len(chartDashboards[13]) != 1 || chartDashboards[13][0] != 7 {
+		t.Errorf("expected chart 13 to stay on dashboard 7, got %v", chartDashboards[13])
+	}
+}
+
+const testAccDashboardChartsResourceConfig = `
+resource "superset_dashboard_charts" "sales" {
+  dashboard_id = 7
+  chart_ids    = [11, 12]
+}
+`
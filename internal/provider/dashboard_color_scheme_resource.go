@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &dashboardColorSchemeResource{}
+	_ resource.ResourceWithConfigure = &dashboardColorSchemeResource{}
+)
+
+// NewDashboardColorSchemeResource is a helper function to simplify the provider implementation.
+func NewDashboardColorSchemeResource() resource.Resource {
+	return &dashboardColorSchemeResource{}
+}
+
+// dashboardColorSchemeResource is the resource implementation.
+type dashboardColorSchemeResource struct {
+	client *client.Client
+}
+
+// dashboardColorSchemeResourceModel maps the resource schema data.
+type dashboardColorSchemeResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	DashboardID types.Int64  `tfsdk:"dashboard_id"`
+	ColorScheme types.String `tfsdk:"color_scheme"`
+	LabelColors types.Map    `tfsdk:"label_colors"`
+}
+
+// Metadata returns the resource type name.
+func (r *dashboardColorSchemeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_color_scheme"
+}
+
+// Schema defines the schema for the resource.
+func (r *dashboardColorSchemeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the categorical color scheme and label colors of an existing Superset dashboard, " +
+			"stored in its json_metadata, so brand palettes stay consistent across environments. " +
+			"Only the attributes set here are managed; the rest of the dashboard is left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the resource, the dashboard ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dashboard_id": schema.Int64Attribute{
+				MarkdownDescription: "Numeric identifier of the dashboard.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"color_scheme": schema.StringAttribute{
+				MarkdownDescription: "Name of the categorical color scheme of the dashboard, e.g. \"supersetColors\" or a scheme registered in EXTRA_CATEGORICAL_COLOR_SCHEMES.",
+				Optional:            true,
+			},
+			"label_colors": schema.MapAttribute{
+				MarkdownDescription: "Colors forced on series labels, keyed by label, e.g. { \"Revenue\" = \"#1FA8C9\" }.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// Create applies the colors to the dashboard and sets the initial Terraform state.
+func (r *dashboardColorSchemeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_dashboard_color_scheme", &resp.Diagnostics) {
+		return
+	}
+
+	var plan dashboardColorSchemeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyColors(ctx, r.client.WithContext(ctx), nil, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(plan.DashboardID.ValueInt64(), 10))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, fmt.Sprintf("Applied color scheme to dashboard ID %d", plan.DashboardID.ValueInt64()))
+}
+
+// Read refreshes the managed colors from the dashboard json_metadata.
+func (r *dashboardColorSchemeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Starting Read method")
+	var state dashboardColorSchemeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata, err := r.client.WithContext(ctx).GetDashboardJSONMetadata(state.DashboardID.ValueInt64())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Debug(ctx, fmt.Sprintf("Dashboard ID %d not found, removing from state", state.DashboardID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading dashboard",
+			fmt.Sprintf("Could not read dashboard ID %d: %s", state.DashboardID.ValueInt64(), err),
+		)
+		return
+	}
+
+	// Only the attributes set in the configuration are refreshed, so colors chosen in the UI
+	// for unmanaged keys do not show up as drift.
+	if !state.ColorScheme.IsNull() {
+		scheme, _ := metadata["color_scheme"].(string)
+		state.ColorScheme = types.StringValue(scheme)
+	}
+	if !state.LabelColors.IsNull() {
+		labelColors := map[string]string{}
+		if remote, ok := metadata["label_colors"].(map[string]interface{}); ok {
+			for label, color := range remote {
+				if value, ok := color.(string); ok {
+					labelColors[label] = value
+				}
+			}
+		}
+		state.LabelColors, diags = types.MapValueFrom(ctx, types.StringType, labelColors)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update applies the changed colors to the dashboard and sets the updated Terraform state on success.
+func (r *dashboardColorSchemeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Starting Update method")
+	if refuseInReadOnlyMode(r.client, "update", "superset_dashboard_color_scheme", &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state dashboardColorSchemeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyColors(ctx, r.client.WithContext(ctx), &state, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the managed colors from the dashboard and removes the Terraform state on success.
+func (r *dashboardColorSchemeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Starting Delete method")
+	if refuseInReadOnlyMode(r.client, "delete", "superset_dashboard_color_scheme", &resp.Diagnostics) {
+		return
+	}
+
+	var state dashboardColorSchemeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cleared := dashboardColorSchemeResourceModel{
+		DashboardID: state.DashboardID,
+		ColorScheme: types.StringNull(),
+		LabelColors: types.MapNull(types.StringType),
+	}
+	r.applyColors(ctx, r.client.WithContext(ctx), &state, &cleared, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// applyColors writes the planned colors into the dashboard json_metadata. Keys managed in the
+// prior state but no longer planned are removed, so Superset falls back to its defaults.
+// A dashboard that no longer exists is ignored when there is nothing left to apply.
+func (r *dashboardColorSchemeResource) applyColors(ctx context.Context, supersetClient *client.Client, prior, plan *dashboardColorSchemeResourceModel, diags *diag.Diagnostics) {
+	dashboardID := plan.DashboardID.ValueInt64()
+	metadata, err := supersetClient.GetDashboardJSONMetadata(dashboardID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) && plan.ColorScheme.IsNull() && plan.LabelColors.IsNull() {
+			return
+		}
+		diags.AddError(
+			"Error reading dashboard",
+			fmt.Sprintf("Could not read dashboard ID %d: %s", dashboardID, err),
+		)
+		return
+	}
+
+	if !plan.ColorScheme.IsNull() {
+		metadata["color_scheme"] = plan.ColorScheme.ValueString()
+	} else if prior != nil && !prior.ColorScheme.IsNull() {
+		delete(metadata, "color_scheme")
+	}
+
+	if !plan.LabelColors.IsNull() {
+		labelColors := map[string]string{}
+		diags.Append(plan.LabelColors.ElementsAs(ctx, &labelColors, false)...)
+		if diags.HasError() {
+			return
+		}
+		metadata["label_colors"] = labelColors
+	} else if prior != nil && !prior.LabelColors.IsNull() {
+		delete(metadata, "label_colors")
+	}
+
+	err = supersetClient.UpdateDashboardJSONMetadata(dashboardID, metadata)
+	if err != nil {
+		diags.AddError(
+			"Error updating dashboard",
+			fmt.Sprintf("Could not update the colors of dashboard ID %d: %s", dashboardID, err),
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *dashboardColorSchemeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccDashboardColorSchemeResource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for the CSRF token
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+
+	// The dashboard keeps the json_metadata last written to it
+	jsonMetadata := `{"refresh_frequency": 0, "color_scheme": "bnbColors"}`
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/dashboard/7?q=(columns:!(id,json_metadata))",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"result": map[string]interface{}{"id": 7, "json_metadata": jsonMetadata},
+			})
+		})
+	httpmock.RegisterResponder("PUT", "http://superset-host/api/v1/dashboard/7",
+		func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				JSONMetadata string `json:"json_metadata"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				return httpmock.NewStringResponse(400, err.Error()), nil
+			}
+			jsonMetadata = payload.JSONMetadata
+			return httpmock.NewStringResponse(200, `{"id": 7, "result": {}}`), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccDashboardColorSchemeResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_dashboard_color_scheme.brand", "id", "7"),
+					resource.TestCheckResourceAttr("superset_dashboard_color_scheme.brand", "color_scheme", "supersetColors"),
+					resource.TestCheckResourceAttr("superset_dashboard_color_scheme.brand", "label_colors.Revenue", "#1FA8C9"),
+				),
+			},
+		},
+	})
+
+	// Destroy removes the managed keys and keeps the rest of the metadata
+	var metadata map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonMetadata), &metadata); err != nil {
+		t.Fatalf("invalid json_metadata after destroy: %s", err)
+	}
+	if _, ok := metadata["color_scheme"]; ok {
+		t.Errorf("expected color_scheme to be removed on destroy, got %s", jsonMetadata)
+	}
+	if _, ok := metadata["label_colors"]; ok {
+		t.Errorf("expected label_colors to be removed on destroy, got %s", jsonMetadata)
+	}
+	if _, ok := metadata["refresh_frequency"]; !ok {
+		t.Errorf("expected unmanaged keys to be kept on destroy, got %s", jsonMetadata)
+	}
+}
+
+const testAccDashboardColorSchemeResourceConfig = `
+resource "superset_dashboard_color_scheme" "brand" {
+  dashboard_id = 7
+  color_scheme = "supersetColors"
+
+  label_colors = {
+    Revenue = "#1FA8C9"
+    Costs   = "#454E7C"
+  }
+}
+`
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dashboardPermalinkDataSource{}
+	_ datasource.DataSourceWithConfigure = &dashboardPermalinkDataSource{}
+)
+
+// NewDashboardPermalinkDataSource is a helper function to simplify the provider implementation.
+func NewDashboardPermalinkDataSource() datasource.DataSource {
+	return &dashboardPermalinkDataSource{}
+}
+
+// dashboardPermalinkDataSource is the data source implementation.
+type dashboardPermalinkDataSource struct {
+	client *client.Client
+}
+
+// dashboardPermalinkDataSourceModel maps the data source schema data.
+type dashboardPermalinkDataSourceModel struct {
+	Dashboard  types.String            `tfsdk:"dashboard"`
+	DataMask   types.String            `tfsdk:"data_mask"`
+	ActiveTabs []types.String          `tfsdk:"active_tabs"`
+	Anchor     types.String            `tfsdk:"anchor"`
+	URLParams  map[string]types.String `tfsdk:"url_params"`
+	Key        types.String            `tfsdk:"key"`
+	URL        types.String            `tfsdk:"url"`
+}
+
+// Metadata returns the data source type name.
+func (d *dashboardPermalinkDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_permalink"
+}
+
+// Schema defines the schema for the data source.
+func (d *dashboardPermalinkDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a share link to a dashboard, optionally with preselected filters and tabs. " +
+			"Superset derives the link from the dashboard and its state, so the same configuration always yields the same URL.",
+		Attributes: map[string]schema.Attribute{
+			"dashboard": schema.StringAttribute{
+				MarkdownDescription: "Numeric identifier or slug of the dashboard.",
+				Required:            true,
+			},
+			"data_mask": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded filter state (Superset's dataMask), keyed by native filter ID.",
+				Optional:            true,
+			},
+			"active_tabs": schema.ListAttribute{
+				MarkdownDescription: "IDs of the dashboard tabs to open.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"anchor": schema.StringAttribute{
+				MarkdownDescription: "ID of the dashboard component to scroll to.",
+				Optional:            true,
+			},
+			"url_params": schema.MapAttribute{
+				MarkdownDescription: "Extra URL parameters added to the dashboard URL, e.g. standalone.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Key of the permalink.",
+				Computed:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Share URL of the dashboard.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dashboardPermalinkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state dashboardPermalinkDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permalinkState := map[string]interface{}{}
+
+	if !state.DataMask.IsNull() {
+		var dataMask map[string]interface{}
+		if err := json.Unmarshal([]byte(state.DataMask.ValueString()), &dataMask); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Data Mask",
+				fmt.Sprintf("data_mask must be a JSON object: %s", err.Error()),
+			)
+			return
+		}
+		permalinkState["dataMask"] = dataMask
+	}
+
+	if state.ActiveTabs != nil {
+		activeTabs := []string{}
+		for _, tab := range state.ActiveTabs {
+			activeTabs = append(activeTabs, tab.ValueString())
+		}
+		permalinkState["activeTabs"] = activeTabs
+	}
+
+	if !state.Anchor.IsNull() {
+		permalinkState["anchor"] = state.Anchor.ValueString()
+	}
+
+	if state.URLParams != nil {
+		// Sort the parameters so the same configuration always sends the same state.
+		names := make([]string, 0, len(state.URLParams))
+		for name := range state.URLParams {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		urlParams := [][]string{}
+		for _, name := range names {
+			urlParams = append(urlParams, []string{name, state.URLParams[name].ValueString()})
+		}
+		permalinkState["urlParams"] = urlParams
+	}
+
+	permalink, err := d.client.CreateDashboardPermalink(state.Dashboard.ValueString(), permalinkState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Superset Dashboard Permalink",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Key = types.StringValue(permalink.Key)
+	state.URL = types.StringValue(permalink.URL)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *dashboardPermalinkDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
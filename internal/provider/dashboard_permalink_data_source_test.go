@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccDashboardPermalinkDataSource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for fetching the CSRF token
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+
+	// Mock the Superset API response for creating a dashboard permalink
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/dashboard/sales/permalink",
+		httpmock.NewStringResponder(201, `{
+			"key": "Yp9Ax3oL2dM",
+			"url": "http://superset-host/superset/dashboard/p/Yp9Ax3oL2dM/"
+		}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccDashboardPermalinkDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_dashboard_permalink.test", "key", "Yp9Ax3oL2dM"),
+					resource.TestCheckResourceAttr("data.superset_dashboard_permalink.test", "url", "http://superset-host/superset/dashboard/p/Yp9Ax3oL2dM/"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDashboardPermalinkDataSourceConfig = `
+data "superset_dashboard_permalink" "test" {
+  dashboard = "sales"
+  data_mask = jsonencode({
+    "NATIVE_FILTER-country" = {
+      filterState = { value = ["DE"] }
+    }
+  })
+  url_params = {
+    standalone = "1"
+  }
+}
+`
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -29,7 +30,9 @@ type databasesDataSource struct {
 
 // databasesDataSourceModel maps the data source schema data.
 type databasesDataSourceModel struct {
-	Databases []databaseModel `tfsdk:"databases"`
+	ID             types.String    `tfsdk:"id"`
+	IncludeSchemas types.Bool      `tfsdk:"include_schemas"`
+	Databases      []databaseModel `tfsdk:"databases"`
 }
 
 // databaseModel maps the database schema data.
@@ -53,29 +56,39 @@ func (d *databasesDataSource) Metadata(ctx context.Context, req datasource.Metad
 func (d *databasesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	tflog.Debug(ctx, "Starting Schema method")
 	resp.Schema = schema.Schema{
-		Description: "Fetches the list of databases and their schemas from Superset.",
+		MarkdownDescription: "Fetches the list of databases and their schemas from Superset.",
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the data source, derived from the Superset host and the IDs of the listed databases, so it only changes when they do.",
+				Computed:            true,
+			},
+			"include_schemas": schema.BoolAttribute{
+				MarkdownDescription: "Whether to fetch the schemas of each database, which takes one extra request per database. " +
+					"Set it to false when the schemas are not needed, to read instances with many connections faster. Defaults to true.",
+				Optional: true,
+			},
 			"databases": schema.ListNestedAttribute{
-				Description: "List of databases.",
-				Computed:    true,
+				MarkdownDescription: "List of databases.",
+				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.Int64Attribute{
-							Description: "Numeric identifier of the database.",
-							Computed:    true,
+							MarkdownDescription: "Numeric identifier of the database.",
+							Computed:            true,
 						},
 						"database_name": schema.StringAttribute{
-							Description: "Name of the database.",
-							Computed:    true,
+							MarkdownDescription: "Name of the database.",
+							Computed:            true,
 						},
 						"schemas": schema.ListAttribute{
-							Description: "List of schemas in the database.",
-							Computed:    true,
-							ElementType: types.StringType,
+							MarkdownDescription: "List of schemas in the database, null when include_schemas is false.",
+							Computed:            true,
+							ElementType:         types.StringType,
 						},
 						"sqlalchemy_uri": schema.StringAttribute{
-							Description: "SQLAlchemy URI of the database.",
-							Computed:    true,
+							MarkdownDescription: "SQLAlchemy URI of the database, with the password masked.",
+							Computed:            true,
+							Sensitive:           true,
 						},
 					},
 				},
@@ -90,8 +103,14 @@ func (d *databasesDataSource) Read(ctx context.Context, req datasource.ReadReque
 	tflog.Debug(ctx, "Starting Read method")
 
 	var state databasesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	dbInfos, err := d.client.GetDatabasesInfos()
+	includeSchemas := state.IncludeSchemas.IsNull() || state.IncludeSchemas.ValueBool()
+	dbInfos, err := d.client.WithContext(ctx).GetDatabasesInfos(includeSchemas)
 	if err != nil {
 		tflog.Error(ctx, "Error fetching database infos", map[string]interface{}{
 			"error": err.Error(),
@@ -114,10 +133,15 @@ func (d *databasesDataSource) Read(ctx context.Context, req datasource.ReadReque
 		)
 		return
 	}
+	if count, ok := dbInfos["count"].(int); ok {
+		warnIfTruncated(&resp.Diagnostics, "databases", len(dbInfosRaw), count)
+	}
 
 	for _, db := range dbInfosRaw {
+		// Only the identifiers are logged: the raw database holds its sqlalchemy_uri and extra settings.
 		tflog.Debug(ctx, "Processing database", map[string]interface{}{
-			"database": db,
+			"id":            db["id"],
+			"database_name": db["database_name"],
 		})
 
 		// Use type assertion to handle int64 type conversion
@@ -167,6 +191,8 @@ func (d *databasesDataSource) Read(ctx context.Context, req datasource.ReadReque
 			for _, schema := range v {
 				schemas = append(schemas, types.StringValue(schema))
 			}
+		case nil:
+			// Schemas were not requested.
 		case []interface{}:
 			for _, schema := range v {
 				schemaStr, ok := schema.(string)
@@ -197,16 +223,39 @@ func (d *databasesDataSource) Read(ctx context.Context, req datasource.ReadReque
 			ID:            types.Int64Value(id),
 			DatabaseName:  types.StringValue(name),
 			Schemas:       schemas,
-			SQLAlchemyURI: types.StringValue(sqlalchemyURI),
+			SQLAlchemyURI: types.StringValue(maskURIPassword(sqlalchemyURI)),
 		})
 	}
 
-	diags := resp.State.Set(ctx, &state)
+	var ids []int64
+	for _, db := range state.Databases {
+		ids = append(ids, db.ID.ValueInt64())
+	}
+	state.ID = listDataSourceID(d.client.Host, "databases", ids)
+
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 
 	tflog.Debug(ctx, "Completed Read method")
 }
 
+// maskedPassword replaces passwords in URIs, as Superset does in its own responses.
+const maskedPassword = "XXXXXXXXXX"
+
+// maskURIPassword masks the password of a URI, in case Superset returned it in clear.
+// URIs that cannot be parsed are returned as is.
+func maskURIPassword(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.User == nil {
+		return uri
+	}
+	if _, hasPassword := parsed.User.Password(); !hasPassword {
+		return uri
+	}
+	parsed.User = url.UserPassword(parsed.User.Username(), maskedPassword)
+	return parsed.String()
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *databasesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	tflog.Debug(ctx, "Starting Configure method")
@@ -2,24 +2,79 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
 
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-superset/internal/client"
 )
 
+// uuidPattern matches the canonical textual representation of a UUID, in lower case as Superset stores and
+// compares it.
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// uuidValidator rejects values that are not a UUID at plan time.
+type uuidValidator struct{}
+
+// Description describes the validation in plain text formatting.
+func (v uuidValidator) Description(_ context.Context) string {
+	return "value must be a UUID in lower case"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v uuidValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v uuidValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || uuidPattern.MatchString(req.ConfigValue.ValueString()) {
+		return
+	}
+
+	if lower := strings.ToLower(req.ConfigValue.ValueString()); uuidPattern.MatchString(lower) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid UUID",
+			fmt.Sprintf("The UUID '%s' must be written in lower case, as Superset stores it: '%s'.", req.ConfigValue.ValueString(), lower),
+		)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid UUID",
+		fmt.Sprintf("The value '%s' is not a valid UUID, e.g. 2f9c3e8a-5b1d-4c7e-9a63-0d8f1b2c4e5a.", req.ConfigValue.ValueString()),
+	)
+}
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &databaseResource{}
-	_ resource.ResourceWithConfigure   = &databaseResource{}
-	_ resource.ResourceWithImportState = &databaseResource{}
+	_ resource.Resource                   = &databaseResource{}
+	_ resource.ResourceWithConfigure      = &databaseResource{}
+	_ resource.ResourceWithImportState    = &databaseResource{}
+	_ resource.ResourceWithUpgradeState   = &databaseResource{}
+	_ resource.ResourceWithModifyPlan     = &databaseResource{}
+	_ resource.ResourceWithValidateConfig = &databaseResource{}
 )
 
 // NewDatabaseResource is a helper function to simplify the provider implementation.
@@ -35,6 +90,7 @@ type databaseResource struct {
 // databaseResourceModel maps the resource schema data.
 type databaseResourceModel struct {
 	ID             types.Int64  `tfsdk:"id"`
+	UUID           types.String `tfsdk:"uuid"`
 	ConnectionName types.String `tfsdk:"connection_name"`
 	DBEngine       types.String `tfsdk:"db_engine"`
 	DBUser         types.String `tfsdk:"db_user"`
@@ -47,6 +103,27 @@ type databaseResourceModel struct {
 	AllowDML       types.Bool   `tfsdk:"allow_dml"`
 	AllowRunAsync  types.Bool   `tfsdk:"allow_run_async"`
 	ExposeInSQLLab types.Bool   `tfsdk:"expose_in_sqllab"`
+
+	DBPassEnv  types.String `tfsdk:"db_pass_env"`
+	DBPassRef  types.String `tfsdk:"db_pass_ref"`
+	DBPassHash types.String `tfsdk:"db_pass_hash"`
+
+	AllowFileUpload             types.Bool     `tfsdk:"allow_file_upload"`
+	SchemasAllowedForFileUpload []types.String `tfsdk:"schemas_allowed_for_file_upload"`
+
+	AllowMultiCatalog         types.Bool `tfsdk:"allow_multi_catalog"`
+	AllowsCostEstimate        types.Bool `tfsdk:"allows_cost_estimate"`
+	AllowsVirtualTableExplore types.Bool `tfsdk:"allows_virtual_table_explore"`
+	DisableDataPreview        types.Bool `tfsdk:"disable_data_preview"`
+
+	Extra            types.String   `tfsdk:"extra"`
+	ExtraManagedKeys []types.String `tfsdk:"extra_managed_keys"`
+
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
+
+	PermissionViewMenu types.String `tfsdk:"permission_view_menu"`
+
+	Timeouts *timeoutsModel `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -57,71 +134,222 @@ func (r *databaseResource) Metadata(_ context.Context, req resource.MetadataRequ
 // Schema defines the schema for the resource.
 func (r *databaseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages a database connection in Superset.",
+		MarkdownDescription: "Manages a database connection in Superset, connecting to the database with a SQLAlchemy URI built from `db_engine`, `db_user`, the password, `db_host`, `db_port` and `db_name`.",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
-				Description: "Numeric identifier of the database connection.",
-				Computed:    true,
+				MarkdownDescription: "Numeric identifier of the database connection.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "UUID of the database connection. Superset exports reference databases by this value. " +
+					"Set it to create the connection with the same UUID in every environment, so dashboards exported from one import cleanly into another. " +
+					"Must be in lower case, as Superset stores it. Generated by the provider when not set; changing it recreates the connection. " +
+					"The create fails, removing the new connection, when Superset does not apply it, and an adopted connection must already have it.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					uuidValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessUnrecorded,
+						"A change of the UUID replaces the connection, unless the state has not recorded it yet.",
+						"A change of the UUID replaces the connection, unless the state has not recorded it yet.",
+					),
+				},
+			},
 			"connection_name": schema.StringAttribute{
-				Description: "Name of the database connection.",
-				Required:    true,
+				MarkdownDescription: "Name of the database connection, as shown in Superset, e.g. \"DWH\". Creating a connection whose name is taken fails unless `adopt_existing` is set.",
+				Required:            true,
 			},
 			"db_engine": schema.StringAttribute{
-				Description: "Database engine (e.g., postgresql, mysql).",
-				Required:    true,
+				MarkdownDescription: "SQLAlchemy dialect of the database, the scheme of the SQLAlchemy URI, e.g. `postgresql`, `mysql` or `trino`. Its driver must be installed in Superset.",
+				Required:            true,
 			},
 			"db_user": schema.StringAttribute{
-				Description: "Database username.",
-				Required:    true,
+				MarkdownDescription: "User the connection logs in to the database as.",
+				Required:            true,
 			},
 			"db_pass": schema.StringAttribute{
-				Description: "Database password.",
-				Required:    true,
-				Sensitive:   true,
+				MarkdownDescription: "Database password. Exactly one of `db_pass`, `db_pass_env` or `db_pass_ref` must be set.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"db_pass_env": schema.StringAttribute{
+				MarkdownDescription: "Name of an environment variable holding the database password. The variable is read by the provider at plan and apply time, so the password never appears in the configuration or the state.",
+				Optional:            true,
+			},
+			"db_pass_ref": schema.StringAttribute{
+				MarkdownDescription: "Reference to the database password in an external secret store (e.g. `vault:kv/data/superset#dwh`), " +
+					"resolved with the provider's `secret_command` at plan and apply time, so the password never appears in the configuration or the state.",
+				Optional: true,
+			},
+			"db_pass_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the password read from `db_pass_env` or `db_pass_ref`, used to detect a rotated password. Null when `db_pass` is used.",
+				Computed:            true,
 			},
 			"db_host": schema.StringAttribute{
-				Description: "Database host.",
-				Required:    true,
+				MarkdownDescription: "Hostname or IP address of the database, e.g. `pg.example.com`.",
+				Required:            true,
 			},
 			"db_port": schema.Int64Attribute{
-				Description: "Database port.",
-				Required:    true,
+				MarkdownDescription: "Port of the database, e.g. 5432 for PostgreSQL.",
+				Required:            true,
 			},
 			"db_name": schema.StringAttribute{
-				Description: "Database name.",
-				Required:    true,
+				MarkdownDescription: "Name of the database to connect to, the path of the SQLAlchemy URI.",
+				Required:            true,
 			},
 			"allow_ctas": schema.BoolAttribute{
-				Description: "Allow CTAS.",
-				Required:    true,
+				MarkdownDescription: "Allow CREATE TABLE AS in SQL Lab, to save the results of a query as a table.",
+				Required:            true,
 			},
 			"allow_cvas": schema.BoolAttribute{
-				Description: "Allow CVAS.",
-				Required:    true,
+				MarkdownDescription: "Allow CREATE VIEW AS in SQL Lab, to save a query as a view.",
+				Required:            true,
 			},
 			"allow_dml": schema.BoolAttribute{
-				Description: "Allow DML.",
-				Required:    true,
+				MarkdownDescription: "Allow SQL Lab to run statements other than SELECT, e.g. INSERT, UPDATE or DROP.",
+				Required:            true,
 			},
 			"allow_run_async": schema.BoolAttribute{
-				Description: "Allow run async.",
-				Required:    true,
+				MarkdownDescription: "Run the SQL Lab queries of the database asynchronously on the Celery workers, for long-running queries. Requires a results backend in Superset.",
+				Required:            true,
 			},
 			"expose_in_sqllab": schema.BoolAttribute{
-				Description: "Expose in SQL Lab.",
-				Required:    true,
+				MarkdownDescription: "Show the database in SQL Lab.",
+				Required:            true,
+			},
+			"allow_file_upload": schema.BoolAttribute{
+				MarkdownDescription: "Allow file (CSV, Excel, columnar) uploads to this database.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"schemas_allowed_for_file_upload": schema.ListAttribute{
+				MarkdownDescription: "Schemas that file uploads are restricted to. Leave unset to allow uploads to any schema.",
+				Optional:            true,
+				ElementType:         types.StringType,
 			},
+			"allow_multi_catalog": schema.BoolAttribute{
+				MarkdownDescription: "Allow browsing every catalog of the database, for engines that support catalogs. Stored in `extra`; leave unset to keep Superset's default.",
+				Optional:            true,
+			},
+			"allows_cost_estimate": schema.BoolAttribute{
+				MarkdownDescription: "Allow estimating the cost of queries in SQL Lab, for engines that support it. Stored as `cost_estimate_enabled` in `extra`; leave unset to keep Superset's default.",
+				Optional:            true,
+			},
+			"allows_virtual_table_explore": schema.BoolAttribute{
+				MarkdownDescription: "Allow exploring SQL Lab query results as virtual datasets. Stored in `extra`; leave unset to keep Superset's default.",
+				Optional:            true,
+			},
+			"disable_data_preview": schema.BoolAttribute{
+				MarkdownDescription: "Disable data previews of tables in SQL Lab. Stored in `extra`; leave unset to keep Superset's default.",
+				Optional:            true,
+			},
+			"extra": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded additional settings (e.g. engine_params, metadata_params) merged into the connection's `extra` field. " +
+					"Only the keys set here are compared with Superset, so keys Superset adds on its own do not cause a diff.",
+				Optional: true,
+			},
+			"extra_managed_keys": schema.ListAttribute{
+				MarkdownDescription: "Top-level keys of `extra` that are managed outside Terraform. They are sent on create and update but never compared with Superset.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Take over the connection already named `connection_name`, updating it to match this configuration, instead of failing the create. " +
+					"Superset allows several connections with the same name, so the create otherwise fails rather than adding a duplicate. " +
+					"Only used on create. Defaults to false.",
+				Optional: true,
+			},
+			"permission_view_menu": schema.StringAttribute{
+				MarkdownDescription: "View menu of the database_access permission of the connection, in the form [connection_name].(id:N), " +
+					"so superset_role_permissions can grant access to it without assembling the string.",
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
 
+// ValidateConfig requires exactly one source for the database password.
+func (r *databaseResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var sources int
+	for _, name := range []string{"db_pass", "db_pass_env", "db_pass_ref"} {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		if value.IsUnknown() {
+			return
+		}
+		if !value.IsNull() {
+			sources++
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if sources != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("db_pass"),
+			"Invalid Password Configuration",
+			"Exactly one of db_pass, db_pass_env or db_pass_ref must be set.",
+		)
+	}
+}
+
+// ModifyPlan resolves db_pass_env and db_pass_ref so that a rotated password shows up as a change.
+// When the password cannot be resolved at plan time the hash is left unknown and resolved on apply.
+// It also plans permission_view_menu, which follows the connection name once the connection has an ID.
+func (r *databaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan databaseResourceModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("id"), &plan.ID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("connection_name"), &plan.ConnectionName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.ID.IsUnknown() && !plan.ID.IsNull() && !plan.ConnectionName.IsUnknown() {
+		viewMenu := types.StringValue(databaseViewMenu(plan.ConnectionName.ValueString(), plan.ID.ValueInt64()))
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("permission_view_menu"), viewMenu)...)
+	}
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("db_pass_env"), &plan.DBPassEnv)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("db_pass_ref"), &plan.DBPassRef)...)
+	if resp.Diagnostics.HasError() || plan.DBPassEnv.IsUnknown() || plan.DBPassRef.IsUnknown() {
+		return
+	}
+
+	hash := types.StringNull()
+	if !plan.DBPassEnv.IsNull() || !plan.DBPassRef.IsNull() {
+		hash = types.StringUnknown()
+		if r.client != nil {
+			if password, err := databasePassword(r.client.WithContext(ctx), plan); err == nil {
+				hash = databasePasswordHash(plan, password)
+			}
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("db_pass_hash"), hash)...)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *databaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_database", &resp.Diagnostics) {
+		return
+	}
+
 	var plan databaseResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -132,31 +360,118 @@ func (r *databaseResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	sqlalchemyURI := fmt.Sprintf("%s://%s:%s@%s:%d/%s", plan.DBEngine.ValueString(), plan.DBUser.ValueString(), plan.DBPass.ValueString(), plan.DBHost.ValueString(), plan.DBPort.ValueInt64(), plan.DBName.ValueString())
-	extra := `{"client_encoding": "utf8"}`
-	payload := map[string]interface{}{
-		"allow_csv_upload":                  false,
-		"allow_ctas":                        plan.AllowCTAS.ValueBool(),
-		"allow_cvas":                        plan.AllowCVAS.ValueBool(),
-		"allow_dml":                         plan.AllowDML.ValueBool(),
-		"allow_multi_schema_metadata_fetch": true,
-		"allow_run_async":                   plan.AllowRunAsync.ValueBool(),
-		"cache_timeout":                     0,
-		"expose_in_sqllab":                  plan.ExposeInSQLLab.ValueBool(),
-		"database_name":                     plan.ConnectionName.ValueString(),
-		"sqlalchemy_uri":                    sqlalchemyURI,
-		"extra":                             extra,
+	ctx, cancel, err := withOperationTimeout(ctx, plan.Timeouts, "create")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Timeout", err.Error())
+		return
+	}
+	defer cancel()
+	supersetClient := r.client.WithContext(ctx)
+
+	password, err := databasePassword(supersetClient, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Resolve Database Password",
+			err.Error(),
+		)
+		return
 	}
+	plan.DBPassHash = databasePasswordHash(plan, password)
 
-	result, err := r.client.CreateDatabase(payload)
+	payload, err := databasePayload(plan, password)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Superset Database Connection",
-			fmt.Sprintf("CreateDatabase failed: %s", err.Error()),
+			"Unable to Build Database Payload",
+			fmt.Sprintf("Could not build the database payload: %s", err.Error()),
+		)
+		return
+	}
+
+	markManagedExternally(supersetClient, payload)
+
+	// The UUID can only be chosen when the connection is created. One is generated when not configured,
+	// so a create whose outcome is unknown can be reconciled.
+	createUUID := uuid.NewString()
+	if !plan.UUID.IsNull() && !plan.UUID.IsUnknown() {
+		createUUID = plan.UUID.ValueString()
+	}
+	payload["uuid"] = createUUID
+
+	// Superset accepts a second connection with the same name, which makes later lookups by name
+	// ambiguous, so the existing connection is either adopted or reported.
+	existingID, err := supersetClient.GetDatabaseIDByName(plan.ConnectionName.ValueString())
+	if errors.Is(err, client.ErrAmbiguousName) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("connection_name"),
+			"Database Connection Already Exists",
+			fmt.Sprintf("Several database connections are already named '%s', so none can be adopted: %s. "+
+				"Remove or rename the duplicates in Superset, or choose another connection_name.", plan.ConnectionName.ValueString(), err.Error()),
+		)
+		return
+	}
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Unable to Check Superset Database Connection Name",
+			fmt.Sprintf("Could not look up database connections named '%s': %s", plan.ConnectionName.ValueString(), err.Error()),
+		)
+		return
+	}
+	adopted := err == nil
+	if adopted && !plan.AdoptExisting.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("connection_name"),
+			"Database Connection Already Exists",
+			fmt.Sprintf("A database connection named '%s' already exists with ID %d. "+
+				"Import it with `terraform import` using ID %d, set adopt_existing = true to take it over, or choose another connection_name.",
+				plan.ConnectionName.ValueString(), existingID, existingID),
 		)
 		return
 	}
 
+	var result map[string]interface{}
+	if adopted {
+		tflog.Info(ctx, fmt.Sprintf("Adopting existing database connection ID %d named '%s'", existingID, plan.ConnectionName.ValueString()))
+		// The UUID of an existing connection cannot be changed, so a pinned UUID must already be its own.
+		if !r.checkPinnedUUID(supersetClient, plan, existingID, &resp.Diagnostics) {
+			return
+		}
+		delete(payload, "uuid")
+		result, err = supersetClient.UpdateDatabase(existingID, payload)
+		if err != nil {
+			addRequestError(&resp.Diagnostics, "Unable to Adopt Superset Database Connection", "UpdateDatabase", err, databaseRequestFields)
+			return
+		}
+	} else {
+		result, err = supersetClient.CreateDatabase(payload)
+		if errors.Is(err, client.ErrOutcomeUnknown) {
+			// The connection may have been created although the response was lost, so it is looked up before creating it again.
+			tflog.Warn(ctx, fmt.Sprintf("Outcome of the create of database connection '%s' is unknown, looking it up: %s", plan.ConnectionName.ValueString(), err))
+			var createdID int64
+			createdID, err = findCreatedDatabase(supersetClient, createUUID, plan.ConnectionName.ValueString())
+			switch {
+			case err == nil:
+				if !r.checkPinnedUUID(supersetClient, plan, createdID, &resp.Diagnostics) {
+					return
+				}
+				delete(payload, "uuid")
+				result, err = supersetClient.UpdateDatabase(createdID, payload)
+			case errors.Is(err, client.ErrNotFound):
+				result, err = supersetClient.CreateDatabase(payload)
+			}
+		}
+		if err != nil {
+			addRequestError(&resp.Diagnostics, "Unable to Create Superset Database Connection", "CreateDatabase", err, databaseRequestFields)
+			return
+		}
+	}
+
+	// An adopted connection existed before this apply, so it is never removed when the create fails.
+	removeCreated := func() {
+		if !adopted {
+			removePartiallyCreated(ctx, r.client, &resp.Diagnostics, "database connection", plan.ID.ValueInt64(), (*client.Client).DeleteDatabase)
+		}
+	}
+
 	// Type assertion with error handling
 	idFloat, ok := result["id"].(float64)
 	if !ok {
@@ -168,12 +483,52 @@ func (r *databaseResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	plan.ID = types.Int64Value(int64(idFloat))
 
+	// Superset may serve reads from a lagging replica, so wait until the new connection is readable.
+	var connection map[string]interface{}
+	err = waitForCreated(ctx, supersetClient, func() error {
+		var err error
+		connection, err = supersetClient.GetDatabaseConnectionByID(plan.ID.ValueInt64())
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Created Superset Database Connection",
+			fmt.Sprintf("Database ID %d was created but could not be read back: %s", plan.ID.ValueInt64(), err.Error()),
+		)
+		removeCreated()
+		return
+	}
+
 	resultData, ok := result["result"].(map[string]interface{})
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Invalid Response",
 			"The response from the API does not contain the expected 'result' field",
 		)
+		removeCreated()
+		return
+	}
+
+	// The update of an adopted connection echoes a payload without uuid, so the connection read back is preferred.
+	connectionData, _ := connection["result"].(map[string]interface{})
+	pinnedUUID := plan.UUID
+	if val, ok := connectionData["uuid"].(string); ok {
+		plan.UUID = types.StringValue(val)
+	} else if val, ok := resultData["uuid"].(string); ok {
+		plan.UUID = types.StringValue(val)
+	} else {
+		plan.UUID = types.StringNull()
+	}
+	if !pinnedUUID.IsNull() && !pinnedUUID.IsUnknown() && pinnedUUID.ValueString() != plan.UUID.ValueString() {
+		// Keeping the connection would taint it, and every apply would create another one.
+		resp.Diagnostics.AddAttributeError(
+			path.Root("uuid"),
+			"Database UUID Not Applied",
+			fmt.Sprintf("Database ID %d was created with UUID '%s' instead of the configured '%s', "+
+				"likely because this Superset version does not accept a UUID on create, so it is removed again. Unset uuid to let Superset choose it.",
+				plan.ID.ValueInt64(), plan.UUID.ValueString(), pinnedUUID.ValueString()),
+		)
+		removeCreated()
 		return
 	}
 
@@ -185,6 +540,7 @@ func (r *databaseResource) Create(ctx context.Context, req resource.CreateReques
 			"Invalid Response",
 			"The response from the API does not contain a valid 'database_name' field",
 		)
+		removeCreated()
 		return
 	}
 	if val, ok := resultData["allow_ctas"].(bool); ok {
@@ -202,6 +558,10 @@ func (r *databaseResource) Create(ctx context.Context, req resource.CreateReques
 	if val, ok := resultData["expose_in_sqllab"].(bool); ok {
 		plan.ExposeInSQLLab = types.BoolValue(val)
 	}
+	if val, ok := resultData["allow_file_upload"].(bool); ok {
+		plan.AllowFileUpload = types.BoolValue(val)
+	}
+	plan.PermissionViewMenu = types.StringValue(databaseViewMenu(plan.ConnectionName.ValueString(), plan.ID.ValueInt64()))
 
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -228,7 +588,14 @@ func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	db, err := r.client.GetDatabaseConnectionByID(state.ID.ValueInt64())
+	ctx, cancel, err := withOperationTimeout(ctx, state.Timeouts, "read")
+	if err != nil {
+		addStoredTimeoutWarning(&resp.Diagnostics, err)
+	}
+	defer cancel()
+	supersetClient := r.client.WithContext(ctx)
+
+	db, err := supersetClient.GetDatabaseConnectionByID(state.ID.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading database connection",
@@ -255,6 +622,9 @@ func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 		)
 		return
 	}
+	if val, ok := result["uuid"].(string); ok {
+		state.UUID = types.StringValue(val)
+	}
 	if val, ok := result["allow_ctas"].(bool); ok {
 		state.AllowCTAS = types.BoolValue(val)
 	}
@@ -270,6 +640,41 @@ func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if val, ok := result["expose_in_sqllab"].(bool); ok {
 		state.ExposeInSQLLab = types.BoolValue(val)
 	}
+	if val, ok := result["allow_file_upload"].(bool); ok {
+		state.AllowFileUpload = types.BoolValue(val)
+	}
+	if val, ok := result["extra"].(string); ok {
+		schemas, err := schemasAllowedForFileUpload(val)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Response",
+				fmt.Sprintf("The 'extra' field returned by the API is not valid JSON: %s", err.Error()),
+			)
+			return
+		}
+		// Keep the attribute null when it is not configured and Superset reports no restriction.
+		if len(schemas) > 0 || state.SchemasAllowedForFileUpload != nil {
+			state.SchemasAllowedForFileUpload = schemas
+		}
+		if err := readDatabaseExtraToggles(&state, val); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Response",
+				fmt.Sprintf("The 'extra' field returned by the API is not valid JSON: %s", err.Error()),
+			)
+			return
+		}
+		if !state.Extra.IsNull() {
+			extra, err := reconcileDatabaseExtra(state.Extra.ValueString(), val, state.ExtraManagedKeys)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid Response",
+					fmt.Sprintf("Could not compare the 'extra' field returned by the API: %s", err.Error()),
+				)
+				return
+			}
+			state.Extra = types.StringValue(extra)
+		}
+	}
 	if val, ok := result["backend"].(string); ok {
 		state.DBEngine = types.StringValue(val)
 	}
@@ -293,6 +698,7 @@ func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 			state.DBPass = types.StringNull()
 		}
 	}
+	state.PermissionViewMenu = types.StringValue(databaseViewMenu(state.ConnectionName.ValueString(), state.ID.ValueInt64()))
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -307,6 +713,10 @@ func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *databaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Starting Update method")
+	if refuseInReadOnlyMode(r.client, "update", "superset_database", &resp.Diagnostics) {
+		return
+	}
+
 	var plan databaseResourceModel
 	var state databaseResourceModel
 
@@ -319,6 +729,14 @@ func (r *databaseResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	ctx, cancel, err := withOperationTimeout(ctx, plan.Timeouts, "update")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Timeout", err.Error())
+		return
+	}
+	defer cancel()
+	supersetClient := r.client.WithContext(ctx)
+
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -328,31 +746,42 @@ func (r *databaseResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	sqlalchemyURI := fmt.Sprintf("%s://%s:%s@%s:%d/%s", plan.DBEngine.ValueString(), plan.DBUser.ValueString(), plan.DBPass.ValueString(), plan.DBHost.ValueString(), plan.DBPort.ValueInt64(), plan.DBName.ValueString())
-	extra := `{"client_encoding": "utf8"}`
-	payload := map[string]interface{}{
-		"allow_csv_upload":                  false,
-		"allow_ctas":                        plan.AllowCTAS.ValueBool(),
-		"allow_cvas":                        plan.AllowCVAS.ValueBool(),
-		"allow_dml":                         plan.AllowDML.ValueBool(),
-		"allow_multi_schema_metadata_fetch": true,
-		"allow_run_async":                   plan.AllowRunAsync.ValueBool(),
-		"cache_timeout":                     0,
-		"expose_in_sqllab":                  plan.ExposeInSQLLab.ValueBool(),
-		"database_name":                     plan.ConnectionName.ValueString(),
-		"sqlalchemy_uri":                    sqlalchemyURI,
-		"extra":                             extra,
+	password, err := databasePassword(supersetClient, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Resolve Database Password",
+			err.Error(),
+		)
+		return
 	}
+	plan.DBPassHash = databasePasswordHash(plan, password)
 
-	result, err := r.client.UpdateDatabase(state.ID.ValueInt64(), payload)
+	payload, err := databasePayload(plan, password)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Update Superset Database Connection",
-			fmt.Sprintf("UpdateDatabase failed: %s", err.Error()),
+			"Unable to Build Database Payload",
+			fmt.Sprintf("Could not build the database payload: %s", err.Error()),
 		)
 		return
 	}
 
+	markManagedExternally(supersetClient, payload)
+
+	// A state upgraded from version 0 may not have recorded the uuid yet, so a configured one is checked
+	// against the connection instead of replacing it.
+	if state.UUID.IsNull() && !plan.UUID.IsUnknown() && !plan.UUID.IsNull() {
+		if !r.checkPinnedUUID(supersetClient, plan, state.ID.ValueInt64(), &resp.Diagnostics) {
+			return
+		}
+		state.UUID = plan.UUID
+	}
+
+	result, err := supersetClient.UpdateDatabase(state.ID.ValueInt64(), payload)
+	if err != nil {
+		addRequestError(&resp.Diagnostics, "Unable to Update Superset Database Connection", "UpdateDatabase", err, databaseRequestFields)
+		return
+	}
+
 	resultData, ok := result["result"].(map[string]interface{})
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -363,6 +792,9 @@ func (r *databaseResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Update state attributes with the values from the response
+	if val, ok := resultData["uuid"].(string); ok {
+		state.UUID = types.StringValue(val)
+	}
 	if val, ok := resultData["database_name"].(string); ok {
 		state.ConnectionName = types.StringValue(val)
 	} else {
@@ -387,13 +819,29 @@ func (r *databaseResource) Update(ctx context.Context, req resource.UpdateReques
 	if val, ok := resultData["expose_in_sqllab"].(bool); ok {
 		state.ExposeInSQLLab = types.BoolValue(val)
 	}
+	if val, ok := resultData["allow_file_upload"].(bool); ok {
+		state.AllowFileUpload = types.BoolValue(val)
+	}
+	state.SchemasAllowedForFileUpload = plan.SchemasAllowedForFileUpload
+	state.AllowMultiCatalog = plan.AllowMultiCatalog
+	state.AllowsCostEstimate = plan.AllowsCostEstimate
+	state.AllowsVirtualTableExplore = plan.AllowsVirtualTableExplore
+	state.DisableDataPreview = plan.DisableDataPreview
+	state.Extra = plan.Extra
+	state.ExtraManagedKeys = plan.ExtraManagedKeys
+	state.AdoptExisting = plan.AdoptExisting
+	state.Timeouts = plan.Timeouts
 
 	state.DBEngine = types.StringValue(plan.DBEngine.ValueString())
 	state.DBUser = types.StringValue(plan.DBUser.ValueString())
-	state.DBPass = types.StringValue(plan.DBPass.ValueString())
+	state.DBPass = plan.DBPass
+	state.DBPassEnv = plan.DBPassEnv
+	state.DBPassRef = plan.DBPassRef
+	state.DBPassHash = plan.DBPassHash
 	state.DBHost = types.StringValue(plan.DBHost.ValueString())
 	state.DBPort = types.Int64Value(plan.DBPort.ValueInt64())
 	state.DBName = types.StringValue(plan.DBName.ValueString())
+	state.PermissionViewMenu = types.StringValue(databaseViewMenu(state.ConnectionName.ValueString(), state.ID.ValueInt64()))
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -410,6 +858,10 @@ func (r *databaseResource) Update(ctx context.Context, req resource.UpdateReques
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *databaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Starting Delete method")
+	if refuseInReadOnlyMode(r.client, "delete", "superset_database", &resp.Diagnostics) {
+		return
+	}
+
 	var state databaseResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -420,7 +872,14 @@ func (r *databaseResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteDatabase(state.ID.ValueInt64())
+	ctx, cancel, err := withOperationTimeout(ctx, state.Timeouts, "delete")
+	if err != nil {
+		addStoredTimeoutWarning(&resp.Diagnostics, err)
+	}
+	defer cancel()
+	supersetClient := r.client.WithContext(ctx)
+
+	err = supersetClient.DeleteDatabase(state.ID.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Delete Superset Database Connection",
@@ -439,30 +898,315 @@ func (r *databaseResource) ImportState(ctx context.Context, req resource.ImportS
 		"import_id": req.ID,
 	})
 
-	// Convert import ID to int64 and set it to the state
+	// Convert import ID to int64 and set it to the state. Anything that is not a number
+	// is treated as the database UUID, as referenced by Superset exports, or else as its name.
 	id, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			fmt.Sprintf("The provided import ID '%s' is not a valid int64: %s", req.ID, err.Error()),
-		)
-		return
+		if connectionUUID := strings.ToLower(req.ID); uuidPattern.MatchString(connectionUUID) {
+			id, err = r.client.GetDatabaseIDByUUID(connectionUUID)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Find Superset Database Connection",
+					fmt.Sprintf("Could not find database with UUID '%s': %s", connectionUUID, err.Error()),
+				)
+				return
+			}
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), connectionUUID)...)
+		} else {
+			id, err = r.client.GetDatabaseIDByName(req.ID)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Find Superset Database Connection",
+					fmt.Sprintf("Could not find database named '%s': %s", req.ID, err.Error()),
+				)
+				return
+			}
+		}
 	}
 
 	// Set the ID in the state and call Read
-	resp.State.SetAttribute(ctx, path.Root("id"), id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Call Read to refresh the state with the latest data
-	r.Read(ctx, resource.ReadRequest{State: resp.State}, &resource.ReadResponse{
-		State:       resp.State,
-		Diagnostics: resp.Diagnostics,
-	})
+	// Populate every attribute Superset returns, so the first plan after the import only shows
+	// the password (never returned by Superset) and the attributes that differ from the configuration.
+	readResp := &resource.ReadResponse{State: resp.State}
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, readResp)
+	resp.Diagnostics.Append(readResp.Diagnostics...)
+	resp.State = readResp.State
 
 	tflog.Debug(ctx, "ImportState completed successfully", map[string]interface{}{
 		"import_id": req.ID,
 	})
 }
 
+// databaseRequestFields maps the fields of the create/update request body to the attributes they are set from.
+var databaseRequestFields = map[string]path.Path{
+	"allow_ctas":        path.Root("allow_ctas"),
+	"allow_cvas":        path.Root("allow_cvas"),
+	"allow_dml":         path.Root("allow_dml"),
+	"allow_file_upload": path.Root("allow_file_upload"),
+	"allow_run_async":   path.Root("allow_run_async"),
+	"database_name":     path.Root("connection_name"),
+	"expose_in_sqllab":  path.Root("expose_in_sqllab"),
+	"extra":             path.Root("extra"),
+	"uuid":              path.Root("uuid"),
+}
+
+// checkPinnedUUID reports whether the connection of the given ID, which existed before the create, has the
+// configured uuid, if any, and adds an error otherwise. It runs before the connection is written to,
+// as the UUID of an existing connection cannot be changed.
+func (r *databaseResource) checkPinnedUUID(c *client.Client, plan databaseResourceModel, id int64, diags *diag.Diagnostics) bool {
+	if plan.UUID.IsNull() || plan.UUID.IsUnknown() {
+		return true
+	}
+
+	pinnedID, err := c.GetDatabaseIDByUUID(plan.UUID.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		diags.AddError(
+			"Unable to Check Superset Database UUID",
+			fmt.Sprintf("Could not look up the database connection with UUID '%s': %s", plan.UUID.ValueString(), err.Error()),
+		)
+		return false
+	}
+	if err != nil || pinnedID != id {
+		diags.AddAttributeError(
+			path.Root("uuid"),
+			"Database UUID Not Applied",
+			fmt.Sprintf("Database ID %d named '%s' does not have the configured UUID '%s', which cannot be changed on an existing connection. "+
+				"Unset uuid, or set it to the UUID of the connection, to manage it.", id, plan.ConnectionName.ValueString(), plan.UUID.ValueString()),
+		)
+		return false
+	}
+	return true
+}
+
+// requiresReplaceUnlessUnrecorded replaces the connection when its uuid changes, unless the state has no
+// uuid yet, as after the upgrade from version 0 when Superset did not return it: the connection keeps its
+// uuid then, and Update checks a configured one.
+func requiresReplaceUnlessUnrecorded(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull()
+}
+
+// findCreatedDatabase looks up the connection a create whose outcome is unknown may have added: by the UUID
+// it was sent with, or else by its name, which Superset versions ignoring the UUID leave as the only match
+// since Create checked that no other connection had it.
+func findCreatedDatabase(c *client.Client, connectionUUID, name string) (int64, error) {
+	id, err := c.GetDatabaseIDByUUID(connectionUUID)
+	if !errors.Is(err, client.ErrNotFound) {
+		return id, err
+	}
+	return c.GetDatabaseIDByName(name)
+}
+
+// databasePayload builds the create/update request body for a database connection from the planned values.
+func databasePayload(plan databaseResourceModel, password string) (map[string]interface{}, error) {
+	sqlalchemyURI := fmt.Sprintf("%s://%s:%s@%s:%d/%s", plan.DBEngine.ValueString(), plan.DBUser.ValueString(), password, plan.DBHost.ValueString(), plan.DBPort.ValueInt64(), plan.DBName.ValueString())
+
+	extra, err := databaseExtra(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"allow_file_upload":                 plan.AllowFileUpload.ValueBool(),
+		"allow_ctas":                        plan.AllowCTAS.ValueBool(),
+		"allow_cvas":                        plan.AllowCVAS.ValueBool(),
+		"allow_dml":                         plan.AllowDML.ValueBool(),
+		"allow_multi_schema_metadata_fetch": true,
+		"allow_run_async":                   plan.AllowRunAsync.ValueBool(),
+		"cache_timeout":                     0,
+		"expose_in_sqllab":                  plan.ExposeInSQLLab.ValueBool(),
+		"database_name":                     plan.ConnectionName.ValueString(),
+		"sqlalchemy_uri":                    sqlalchemyURI,
+		"extra":                             extra,
+	}, nil
+}
+
+// databasePassword returns the connection password, reading it from the environment when db_pass_env
+// is set and from the provider's secret command when db_pass_ref is set.
+func databasePassword(c *client.Client, plan databaseResourceModel) (string, error) {
+	switch {
+	case !plan.DBPassEnv.IsNull():
+		name := plan.DBPassEnv.ValueString()
+		password, ok := os.LookupEnv(name)
+		if !ok || password == "" {
+			return "", fmt.Errorf("environment variable %q referenced by db_pass_env is not set", name)
+		}
+		return password, nil
+	case !plan.DBPassRef.IsNull():
+		password, err := c.ResolveSecret(plan.DBPassRef.ValueString())
+		if errors.Is(err, client.ErrNoSecretCommand) {
+			return "", fmt.Errorf("db_pass_ref requires secret_command to be set in the provider configuration")
+		}
+		return password, err
+	default:
+		return plan.DBPass.ValueString(), nil
+	}
+}
+
+// databasePasswordHash returns the db_pass_hash value for a password resolved from db_pass_env or db_pass_ref.
+func databasePasswordHash(plan databaseResourceModel, password string) types.String {
+	if plan.DBPassEnv.IsNull() && plan.DBPassRef.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(hashPassword(password))
+}
+
+// hashPassword returns the hex encoded SHA-256 hash stored in db_pass_hash.
+func hashPassword(password string) string {
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:])
+}
+
+// databaseExtraToggles maps the keys of the "extra" field to the boolean attributes stored in them.
+func databaseExtraToggles(model *databaseResourceModel) map[string]*types.Bool {
+	return map[string]*types.Bool{
+		"allow_multi_catalog":          &model.AllowMultiCatalog,
+		"cost_estimate_enabled":        &model.AllowsCostEstimate,
+		"allows_virtual_table_explore": &model.AllowsVirtualTableExplore,
+		"disable_data_preview":         &model.DisableDataPreview,
+	}
+}
+
+// readDatabaseExtraToggles refreshes the configured toggle attributes from a JSON encoded "extra" field.
+// Unconfigured toggles stay null, so keys Superset or the user supplied extra set on their own do not
+// cause a diff; a configured toggle whose key is gone is nulled so Terraform plans to restore it.
+func readDatabaseExtraToggles(model *databaseResourceModel, extra string) error {
+	parsed := map[string]interface{}{}
+	if extra != "" {
+		if err := json.Unmarshal([]byte(extra), &parsed); err != nil {
+			return err
+		}
+	}
+
+	for key, attribute := range databaseExtraToggles(model) {
+		if attribute.IsNull() {
+			continue
+		}
+		if value, ok := parsed[key].(bool); ok {
+			*attribute = types.BoolValue(value)
+		} else {
+			*attribute = types.BoolNull()
+		}
+	}
+	return nil
+}
+
+// databaseExtra builds the JSON encoded "extra" field of a database connection.
+// The user supplied extra is merged over the provider defaults; schemas_allowed_for_file_upload
+// is taken from its dedicated attribute unless only the user supplied extra sets it, and the
+// configured toggle attributes override the keys they are stored in.
+func databaseExtra(plan databaseResourceModel) (string, error) {
+	extra := map[string]interface{}{
+		"client_encoding": "utf8",
+	}
+
+	if !plan.Extra.IsNull() && plan.Extra.ValueString() != "" {
+		var userExtra map[string]interface{}
+		if err := json.Unmarshal([]byte(plan.Extra.ValueString()), &userExtra); err != nil {
+			return "", fmt.Errorf("extra must be a JSON object: %w", err)
+		}
+		for key, value := range userExtra {
+			extra[key] = value
+		}
+	}
+
+	if _, ok := extra["schemas_allowed_for_file_upload"]; !ok || plan.SchemasAllowedForFileUpload != nil {
+		schemas := []string{}
+		for _, schema := range plan.SchemasAllowedForFileUpload {
+			schemas = append(schemas, schema.ValueString())
+		}
+		extra["schemas_allowed_for_file_upload"] = schemas
+	}
+
+	for key, attribute := range databaseExtraToggles(&plan) {
+		if !attribute.IsNull() {
+			extra[key] = attribute.ValueBool()
+		}
+	}
+
+	extraJSON, err := json.Marshal(extra)
+	if err != nil {
+		return "", err
+	}
+	return string(extraJSON), nil
+}
+
+// schemasAllowedForFileUpload extracts the schemas_allowed_for_file_upload list from a JSON encoded "extra" field.
+func schemasAllowedForFileUpload(extra string) ([]types.String, error) {
+	if extra == "" {
+		return nil, nil
+	}
+
+	var parsed struct {
+		SchemasAllowedForFileUpload []string `json:"schemas_allowed_for_file_upload"`
+	}
+	if err := json.Unmarshal([]byte(extra), &parsed); err != nil {
+		return nil, err
+	}
+
+	var schemas []types.String
+	for _, schema := range parsed.SchemasAllowedForFileUpload {
+		schemas = append(schemas, types.StringValue(schema))
+	}
+	return schemas, nil
+}
+
+// reconcileDatabaseExtra compares the extra kept in state with the one returned by Superset.
+// Only the top-level keys present in the prior value are compared, and managed keys are skipped,
+// so keys Superset adds on its own never cause a diff. When every compared key matches, the prior
+// value is returned unchanged to preserve the user's formatting; otherwise the compared keys are
+// rebuilt from the remote values so Terraform plans to restore them.
+func reconcileDatabaseExtra(prior, remote string, managedKeys []types.String) (string, error) {
+	var priorExtra map[string]interface{}
+	if err := json.Unmarshal([]byte(prior), &priorExtra); err != nil {
+		return "", err
+	}
+
+	remoteExtra := map[string]interface{}{}
+	if remote != "" {
+		if err := json.Unmarshal([]byte(remote), &remoteExtra); err != nil {
+			return "", err
+		}
+	}
+
+	managed := make(map[string]bool, len(managedKeys))
+	for _, key := range managedKeys {
+		managed[key.ValueString()] = true
+	}
+
+	drifted := false
+	reconciled := make(map[string]interface{}, len(priorExtra))
+	for key, value := range priorExtra {
+		if managed[key] {
+			reconciled[key] = value
+			continue
+		}
+		remoteValue, ok := remoteExtra[key]
+		if !ok {
+			drifted = true
+			continue
+		}
+		if !reflect.DeepEqual(value, remoteValue) {
+			drifted = true
+		}
+		reconciled[key] = remoteValue
+	}
+
+	if !drifted {
+		return prior, nil
+	}
+
+	reconciledJSON, err := json.Marshal(reconciled)
+	if err != nil {
+		return "", err
+	}
+	return string(reconciledJSON), nil
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *databaseResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -480,3 +1224,83 @@ func (r *databaseResource) Configure(_ context.Context, req resource.ConfigureRe
 
 	r.client = client
 }
+
+// databaseResourceModelV0 maps the version 0 schema data, before uuid and the file upload settings were added.
+type databaseResourceModelV0 struct {
+	ID             types.Int64  `tfsdk:"id"`
+	ConnectionName types.String `tfsdk:"connection_name"`
+	DBEngine       types.String `tfsdk:"db_engine"`
+	DBUser         types.String `tfsdk:"db_user"`
+	DBPass         types.String `tfsdk:"db_pass"`
+	DBHost         types.String `tfsdk:"db_host"`
+	DBPort         types.Int64  `tfsdk:"db_port"`
+	DBName         types.String `tfsdk:"db_name"`
+	AllowCTAS      types.Bool   `tfsdk:"allow_ctas"`
+	AllowCVAS      types.Bool   `tfsdk:"allow_cvas"`
+	AllowDML       types.Bool   `tfsdk:"allow_dml"`
+	AllowRunAsync  types.Bool   `tfsdk:"allow_run_async"`
+	ExposeInSQLLab types.Bool   `tfsdk:"expose_in_sqllab"`
+}
+
+// UpgradeState upgrades prior versions of the resource state to the current schema.
+func (r *databaseResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":               schema.Int64Attribute{Computed: true},
+					"connection_name":  schema.StringAttribute{Required: true},
+					"db_engine":        schema.StringAttribute{Required: true},
+					"db_user":          schema.StringAttribute{Required: true},
+					"db_pass":          schema.StringAttribute{Required: true, Sensitive: true},
+					"db_host":          schema.StringAttribute{Required: true},
+					"db_port":          schema.Int64Attribute{Required: true},
+					"db_name":          schema.StringAttribute{Required: true},
+					"allow_ctas":       schema.BoolAttribute{Required: true},
+					"allow_cvas":       schema.BoolAttribute{Required: true},
+					"allow_dml":        schema.BoolAttribute{Required: true},
+					"allow_run_async":  schema.BoolAttribute{Required: true},
+					"expose_in_sqllab": schema.BoolAttribute{Required: true},
+				},
+			},
+			StateUpgrader: upgradeDatabaseStateV0toV1,
+		},
+	}
+}
+
+// upgradeDatabaseStateV0toV1 carries over the version 0 attributes and fills in the added ones.
+// The uuid is left null and populated by the next refresh, and never plans a replacement while null;
+// allow_file_upload takes its schema
+// default, which matches what the provider sent before the attribute existed.
+func upgradeDatabaseStateV0toV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior databaseResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := databaseResourceModel{
+		ID:              prior.ID,
+		UUID:            types.StringNull(),
+		ConnectionName:  prior.ConnectionName,
+		DBEngine:        prior.DBEngine,
+		DBUser:          prior.DBUser,
+		DBPass:          prior.DBPass,
+		DBPassEnv:       types.StringNull(),
+		DBPassRef:       types.StringNull(),
+		DBPassHash:      types.StringNull(),
+		DBHost:          prior.DBHost,
+		DBPort:          prior.DBPort,
+		DBName:          prior.DBName,
+		AllowCTAS:       prior.AllowCTAS,
+		AllowCVAS:       prior.AllowCVAS,
+		AllowDML:        prior.AllowDML,
+		AllowRunAsync:   prior.AllowRunAsync,
+		ExposeInSQLLab:  prior.ExposeInSQLLab,
+		AllowFileUpload: types.BoolValue(false),
+
+		PermissionViewMenu: types.StringValue(databaseViewMenu(prior.ConnectionName.ValueString(), prior.ID.ValueInt64())),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+}
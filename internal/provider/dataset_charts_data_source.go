@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &datasetChartsDataSource{}
+	_ datasource.DataSourceWithConfigure = &datasetChartsDataSource{}
+)
+
+// NewDatasetChartsDataSource is a helper function to simplify the provider implementation.
+func NewDatasetChartsDataSource() datasource.DataSource {
+	return &datasetChartsDataSource{}
+}
+
+// datasetChartsDataSource is the data source implementation.
+type datasetChartsDataSource struct {
+	client *client.Client
+}
+
+// datasetChartsDataSourceModel maps the data source schema data.
+type datasetChartsDataSourceModel struct {
+	ID        types.String        `tfsdk:"id"`
+	DatasetID types.Int64         `tfsdk:"dataset_id"`
+	Charts    []datasetChartModel `tfsdk:"charts"`
+}
+
+// datasetChartModel maps the chart schema data.
+type datasetChartModel struct {
+	ID      types.Int64  `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	VizType types.String `tfsdk:"viz_type"`
+}
+
+// Metadata returns the data source type name.
+func (d *datasetChartsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset_charts"
+}
+
+// Schema defines the schema for the data source.
+func (d *datasetChartsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the charts built on a dataset, e.g. to check that a dataset is no longer used before removing it. " +
+			"Fails when the dataset does not exist, so a mistyped ID is not mistaken for an unused dataset.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the data source, derived from the Superset host, the dataset ID and the IDs of the listed charts, so it only changes when they do.",
+				Computed:            true,
+			},
+			"dataset_id": schema.Int64Attribute{
+				MarkdownDescription: "Numeric identifier of the dataset.",
+				Required:            true,
+			},
+			"charts": schema.ListNestedAttribute{
+				MarkdownDescription: "Charts built on the dataset, sorted by ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Numeric identifier of the chart.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the chart.",
+							Computed:            true,
+						},
+						"viz_type": schema.StringAttribute{
+							MarkdownDescription: "Visualization type of the chart, e.g. table.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *datasetChartsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datasetChartsDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	datasetID := state.DatasetID.ValueInt64()
+	charts, err := d.client.WithContext(ctx).GetDatasetCharts(datasetID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Superset Dataset Charts",
+			fmt.Sprintf("Could not read the charts of dataset ID %d: %s", datasetID, err),
+		)
+		return
+	}
+
+	state.Charts = []datasetChartModel{}
+	ids := []int64{datasetID}
+	for _, chart := range charts {
+		state.Charts = append(state.Charts, datasetChartModel{
+			ID:      types.Int64Value(chart.ID),
+			Name:    types.StringValue(chart.SliceName),
+			VizType: types.StringValue(chart.VizType),
+		})
+		ids = append(ids, chart.ID)
+	}
+	state.ID = listDataSourceID(d.client.Host, "dataset_charts", ids)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *datasetChartsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
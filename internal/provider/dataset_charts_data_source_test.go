@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccDatasetChartsDataSource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for the related objects of a dataset
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/dataset/7/related_objects",
+		httpmock.NewStringResponder(200, `{
+			"charts": {
+				"count": 2,
+				"result": [
+					{"id": 12, "slice_name": "Payments by day", "viz_type": "echarts_timeseries_line"},
+					{"id": 3, "slice_name": "Top merchants", "viz_type": "table"}
+				]
+			},
+			"dashboards": {
+				"count": 1,
+				"result": [{"id": 1, "title": "Payments", "slug": "payments"}]
+			}
+		}`))
+
+	// Mock the Superset API response for a dataset that does not exist
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/dataset/8/related_objects",
+		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccDatasetChartsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_dataset_charts.test", "charts.#", "2"),
+					resource.TestCheckResourceAttr("data.superset_dataset_charts.test", "charts.0.id", "3"),
+					resource.TestCheckResourceAttr("data.superset_dataset_charts.test", "charts.0.name", "Top merchants"),
+					resource.TestCheckResourceAttr("data.superset_dataset_charts.test", "charts.0.viz_type", "table"),
+					resource.TestCheckResourceAttr("data.superset_dataset_charts.test", "charts.1.id", "12"),
+					resource.TestCheckResourceAttrSet("data.superset_dataset_charts.test", "id"),
+				),
+			},
+			// A missing dataset is an error rather than an empty list
+			{
+				Config:      providerConfig + testAccDatasetChartsDataSourceMissingConfig,
+				ExpectError: regexp.MustCompile("Unable to Read Superset Dataset Charts"),
+			},
+		},
+	})
+}
+
+const testAccDatasetChartsDataSourceConfig = `
+data "superset_dataset_charts" "test" {
+  dataset_id = 7
+}
+`
+
+const testAccDatasetChartsDataSourceMissingConfig = `
+data "superset_dataset_charts" "test" {
+  dataset_id = 8
+}
+`
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &datasetColumnsSyncResource{}
+	_ resource.ResourceWithConfigure = &datasetColumnsSyncResource{}
+)
+
+// NewDatasetColumnsSyncResource is a helper function to simplify the provider implementation.
+func NewDatasetColumnsSyncResource() resource.Resource {
+	return &datasetColumnsSyncResource{}
+}
+
+// datasetColumnsSyncResource is the resource implementation.
+type datasetColumnsSyncResource struct {
+	client *client.Client
+}
+
+// datasetColumnsSyncResourceModel maps the resource schema data.
+type datasetColumnsSyncResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	DatasetID     types.Int64  `tfsdk:"dataset_id"`
+	Triggers      types.Map    `tfsdk:"triggers"`
+	LastRefreshed types.String `tfsdk:"last_refreshed"`
+}
+
+// Metadata returns the resource type name.
+func (r *datasetColumnsSyncResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset_columns_sync"
+}
+
+// Schema defines the schema for the resource.
+func (r *datasetColumnsSyncResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Syncs the columns of a Superset dataset from its underlying table. " +
+			"The sync runs when the resource is created and again whenever dataset_id or triggers change; " +
+			"destroying the resource leaves the dataset untouched. Useful for datasets not managed by Terraform, " +
+			"e.g. after a warehouse migration renames columns.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the sync, the dataset ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dataset_id": schema.Int64Attribute{
+				MarkdownDescription: "Numeric identifier of the dataset whose columns are synced.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that trigger a new sync when changed, e.g. a schema hash or migration version.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"last_refreshed": schema.StringAttribute{
+				MarkdownDescription: "Timestamp of the last sync.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create syncs the dataset columns and sets the initial Terraform state.
+func (r *datasetColumnsSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_dataset_columns_sync", &resp.Diagnostics) {
+		return
+	}
+
+	var plan datasetColumnsSyncResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		tflog.Debug(ctx, "Exiting Create due to error in retrieving plan", map[string]interface{}{
+			"diagnostics": resp.Diagnostics,
+		})
+		return
+	}
+
+	datasetID := plan.DatasetID.ValueInt64()
+	err := r.client.WithContext(ctx).RefreshDatasetColumns(datasetID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Sync Superset Dataset Columns",
+			fmt.Sprintf("RefreshDatasetColumns failed for dataset ID %d: %s", datasetID, err.Error()),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(datasetID, 10))
+	plan.LastRefreshed = types.StringValue(time.Now().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		tflog.Debug(ctx, "Exiting Create due to error in setting state", map[string]interface{}{
+			"diagnostics": resp.Diagnostics,
+		})
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Synced columns of dataset ID %d", datasetID))
+}
+
+// Read keeps the state as is; the sync has no remote object to refresh.
+func (r *datasetColumnsSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Starting Read method")
+	var state datasetColumnsSyncResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only stores the plan, since every attribute change replaces the resource and syncs again.
+func (r *datasetColumnsSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Starting Update method")
+	var plan datasetColumnsSyncResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state without changing the dataset.
+func (r *datasetColumnsSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Starting Delete method")
+	resp.State.RemoveResource(ctx)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *datasetColumnsSyncResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccDatasetColumnsSyncResource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for the CSRF token
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+
+	// Mock the Superset API response for refreshing the dataset columns
+	httpmock.RegisterResponder("PUT", "http://superset-host/api/v1/dataset/42/refresh",
+		httpmock.NewStringResponder(200, `{"message": "OK"}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create syncs the columns
+			{
+				Config: providerConfig + testAccDatasetColumnsSyncResourceConfig("v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_dataset_columns_sync.test", "id", "42"),
+					resource.TestCheckResourceAttr("superset_dataset_columns_sync.test", "triggers.migration", "v1"),
+					resource.TestCheckResourceAttrSet("superset_dataset_columns_sync.test", "last_refreshed"),
+				),
+			},
+			// Changing a trigger syncs again
+			{
+				Config: providerConfig + testAccDatasetColumnsSyncResourceConfig("v2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_dataset_columns_sync.test", "triggers.migration", "v2"),
+					func(_ *terraform.State) error {
+						if calls := httpmock.GetCallCountInfo()["PUT http://superset-host/api/v1/dataset/42/refresh"]; calls != 2 {
+							return fmt.Errorf("expected 2 column syncs, got %d", calls)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasetColumnsSyncResourceConfig(migration string) string {
+	return fmt.Sprintf(`
+resource "superset_dataset_columns_sync" "test" {
+  dataset_id = 42
+
+  triggers = {
+    migration = %q
+  }
+}
+`, migration)
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// ignorePermissionModel maps an ignore_permissions rule. Each field is an exact name,
+// or a regular expression when wrapped in slashes, e.g. "/^can_.*$/".
+type ignorePermissionModel struct {
+	Permission types.String `tfsdk:"permission"`
+	ViewMenu   types.String `tfsdk:"view_menu"`
+}
+
+// permissionRule is a compiled ignore_permissions rule.
+type permissionRule struct {
+	permission nameMatcher
+	viewMenu   nameMatcher
+}
+
+// nameMatcher matches a name exactly, or against a regular expression when set.
+type nameMatcher struct {
+	exact   string
+	pattern *regexp.Regexp
+}
+
+// matches reports whether the name is matched.
+func (m nameMatcher) matches(name string) bool {
+	if m.pattern != nil {
+		return m.pattern.MatchString(name)
+	}
+	return m.exact == name
+}
+
+// compileNameMatcher compiles an exact name, or a regular expression wrapped in slashes.
+func compileNameMatcher(value string) (nameMatcher, error) {
+	if len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		pattern, err := regexp.Compile(value[1 : len(value)-1])
+		if err != nil {
+			return nameMatcher{}, err
+		}
+		return nameMatcher{pattern: pattern}, nil
+	}
+	return nameMatcher{exact: value}, nil
+}
+
+// compilePermissionRules compiles the ignore_permissions rules of the configuration.
+func compilePermissionRules(rules []ignorePermissionModel) ([]permissionRule, error) {
+	var compiled []permissionRule
+	for _, rule := range rules {
+		permission, err := compileNameMatcher(rule.Permission.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore_permissions permission %q: %w", rule.Permission.ValueString(), err)
+		}
+		viewMenu, err := compileNameMatcher(rule.ViewMenu.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore_permissions view_menu %q: %w", rule.ViewMenu.ValueString(), err)
+		}
+		compiled = append(compiled, permissionRule{permission: permission, viewMenu: viewMenu})
+	}
+	return compiled, nil
+}
+
+// ignoresPermission reports whether a permission granted in Superset matches one of the rules.
+func ignoresPermission(rules []permissionRule, permission client.Permission) bool {
+	for _, rule := range rules {
+		if rule.permission.matches(permission.PermissionName) && rule.viewMenu.matches(permission.ViewMenuName) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoredPermissions returns the permissions currently granted to the role that match the rules,
+// so applying the configured permissions keeps them instead of stripping them.
+func ignoredPermissions(supersetClient *client.Client, roleID int64, rules []permissionRule) ([]client.Permission, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	granted, err := supersetClient.GetRolePermissions(roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ignored []client.Permission
+	for _, permission := range granted {
+		if ignoresPermission(rules, permission) {
+			ignored = append(ignored, permission)
+		}
+	}
+	return ignored, nil
+}
+
+// nameMatcherValidator rejects regular expressions that do not compile at plan time.
+type nameMatcherValidator struct{}
+
+// Description describes the validation in plain text formatting.
+func (v nameMatcherValidator) Description(_ context.Context) string {
+	return "value must be an exact name or a valid regular expression wrapped in slashes"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v nameMatcherValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v nameMatcherValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := compileNameMatcher(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("The value %q is wrapped in slashes but is not a valid regular expression: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
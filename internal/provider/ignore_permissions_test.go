@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+func TestIgnoresPermission(t *testing.T) {
+	rules, err := compilePermissionRules([]ignorePermissionModel{
+		{Permission: types.StringValue("menu_access"), ViewMenu: types.StringValue("/.*/")},
+		{Permission: types.StringValue("/^can_(read|write)$/"), ViewMenu: types.StringValue("[Trino].(id:34)")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cases := []struct {
+		permission client.Permission
+		expected   bool
+	}{
+		{client.Permission{PermissionName: "menu_access", ViewMenuName: "SQL Lab"}, true},
+		{client.Permission{PermissionName: "can_read", ViewMenuName: "[Trino].(id:34)"}, true},
+		{client.Permission{PermissionName: "can_write", ViewMenuName: "[Trino].(id:34)"}, true},
+		{client.Permission{PermissionName: "can_read", ViewMenuName: "[Trino].(id:35)"}, false},
+		{client.Permission{PermissionName: "can_export", ViewMenuName: "[Trino].(id:34)"}, false},
+		{client.Permission{PermissionName: "menu_access_all", ViewMenuName: "SQL Lab"}, false},
+	}
+
+	for _, c := range cases {
+		if got := ignoresPermission(rules, c.permission); got != c.expected {
+			t.Errorf("%s on %s: expected %t, got %t", c.permission.PermissionName, c.permission.ViewMenuName, c.expected, got)
+		}
+	}
+
+	_, err = compilePermissionRules([]ignorePermissionModel{
+		{Permission: types.StringValue("/can_(/"), ViewMenu: types.StringValue("Chart")},
+	})
+	if err == nil {
+		t.Error("expected an error for an invalid regular expression")
+	}
+}
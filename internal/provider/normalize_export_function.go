@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &normalizeExportFunction{}
+)
+
+// volatileExportKeys are the keys dropped from exports because they differ between environments or
+// exports of the same object: numeric IDs, which each instance assigns on its own, and audit fields.
+// Objects are matched across environments by their uuid, which is kept.
+var volatileExportKeys = []string{
+	"id",
+	"chartId",
+	"slice_id",
+	"dashboard_id",
+	"database_id",
+	"datasource_id",
+	"changed_by",
+	"changed_by_name",
+	"changed_on",
+	"changed_on_delta_humanized",
+	"changed_on_utc",
+	"created_by",
+	"created_by_name",
+	"created_on",
+	"created_on_delta_humanized",
+	"last_modified_time",
+	"timestamp",
+}
+
+// NewNormalizeExportFunction is a helper function to simplify the provider implementation.
+func NewNormalizeExportFunction() function.Function {
+	return &normalizeExportFunction{}
+}
+
+// normalizeExportFunction is the function implementation.
+type normalizeExportFunction struct{}
+
+// Metadata returns the function name.
+func (f *normalizeExportFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_export"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *normalizeExportFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a Superset export for diffing",
+		MarkdownDescription: "Normalizes a chart, dashboard, dataset or database export of Superset, in YAML as found in export bundles or in JSON as returned by the API, " +
+			"so exports of the same objects from different environments compare equal. Numeric IDs and audit fields (" + strings.Join(volatileExportKeys, ", ") + ") " +
+			"are removed at any depth, JSON objects embedded in strings (e.g. json_metadata, params) are normalized as well, " +
+			"and the result is JSON with sorted keys.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "content",
+				MarkdownDescription: "The exported YAML or JSON document.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "ignore_keys",
+			MarkdownDescription: "Additional keys to remove, e.g. owners.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the export.
+func (f *normalizeExportFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content string
+	var ignoreKeys []string
+	resp.Error = req.Arguments.Get(ctx, &content, &ignoreKeys)
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeExport(content, append(append([]string{}, volatileExportKeys...), ignoreKeys...))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
+
+// normalizeExport parses a YAML or JSON document, removes the given keys and returns it as JSON with sorted keys.
+func normalizeExport(content string, ignoreKeys []string) (string, error) {
+	// JSON is a subset of YAML, so both formats are read by the YAML decoder.
+	var document interface{}
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		return "", fmt.Errorf("the export is neither valid YAML nor valid JSON: %w", err)
+	}
+	if document == nil {
+		return "", fmt.Errorf("the export is empty")
+	}
+
+	ignored := make(map[string]bool, len(ignoreKeys))
+	for _, key := range ignoreKeys {
+		ignored[key] = true
+	}
+
+	normalized, err := json.Marshal(normalizeExportValue(document, ignored))
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+// normalizeExportValue removes the ignored keys from value at any depth. json.Marshal sorts the keys of
+// the maps it returns, which makes the encoding canonical.
+func normalizeExportValue(value interface{}, ignored map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if !ignored[key] {
+				normalized[key] = normalizeExportValue(item, ignored)
+			}
+		}
+		return normalized
+	case map[interface{}]interface{}:
+		// YAML allows keys that are not strings, which JSON does not.
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if name := fmt.Sprint(key); !ignored[name] {
+				normalized[name] = normalizeExportValue(item, ignored)
+			}
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeExportValue(item, ignored)
+		}
+		return normalized
+	case string:
+		// The API returns json_metadata, position_json, params and query_context as JSON strings.
+		trimmed := strings.TrimSpace(v)
+		if !strings.HasPrefix(trimmed, "{") {
+			return v
+		}
+		var embedded map[string]interface{}
+		if err := json.Unmarshal([]byte(trimmed), &embedded); err != nil {
+			return v
+		}
+		encoded, err := json.Marshal(normalizeExportValue(embedded, ignored))
+		if err != nil {
+			return v
+		}
+		return string(encoded)
+	default:
+		return v
+	}
+}
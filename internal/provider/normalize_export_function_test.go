@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeExportFunction(t *testing.T) {
+	staging := `
+slice_name: Payments by day
+description: null
+viz_type: echarts_timeseries_line
+params:
+  datasource: 12__table
+  slice_id: 101
+  viz_type: echarts_timeseries_line
+  metrics: [count]
+cache_timeout: null
+uuid: 3f6c2c7e-2f0e-4a4e-9b2b-6a4bd6b0a1c2
+version: 1.0.0
+dataset_uuid: 9c2e8a2b-4b5f-4c5b-8f3a-1d2e3f4a5b6c
+changed_on: 2024-03-01T10:00:00
+`
+	production := `{
+		"version": "1.0.0",
+		"uuid": "3f6c2c7e-2f0e-4a4e-9b2b-6a4bd6b0a1c2",
+		"id": 87,
+		"slice_name": "Payments by day",
+		"description": null,
+		"viz_type": "echarts_timeseries_line",
+		"cache_timeout": null,
+		"dataset_uuid": "9c2e8a2b-4b5f-4c5b-8f3a-1d2e3f4a5b6c",
+		"params": {"metrics": ["count"], "viz_type": "echarts_timeseries_line", "slice_id": 87, "datasource": "12__table"},
+		"changed_on": "2024-05-17T08:30:00"
+	}`
+
+	expected := `{"cache_timeout":null,"dataset_uuid":"9c2e8a2b-4b5f-4c5b-8f3a-1d2e3f4a5b6c","description":null,` +
+		`"params":{"datasource":"12__table","metrics":["count"],"viz_type":"echarts_timeseries_line"},` +
+		`"slice_name":"Payments by day","uuid":"3f6c2c7e-2f0e-4a4e-9b2b-6a4bd6b0a1c2","version":"1.0.0","viz_type":"echarts_timeseries_line"}`
+
+	for name, content := range map[string]string{"YAML": staging, "JSON": production} {
+		t.Run(name, func(t *testing.T) {
+			if got := runNormalizeExport(t, content); got != expected {
+				t.Errorf("Expected %s, got %s", expected, got)
+			}
+		})
+	}
+
+	t.Run("EmbeddedJSON", func(t *testing.T) {
+		content := `{"dashboard_title": "Payments", "json_metadata": "{\"refresh_frequency\": 0, \"positions\": {\"CHART-1\": {\"meta\": {\"chartId\": 87, \"uuid\": \"3f6c\"}}}}"}`
+		expected := `{"dashboard_title":"Payments","json_metadata":"{\"positions\":{\"CHART-1\":{\"meta\":{\"uuid\":\"3f6c\"}}},\"refresh_frequency\":0}"}`
+		if got := runNormalizeExport(t, content); got != expected {
+			t.Errorf("Expected %s, got %s", expected, got)
+		}
+	})
+
+	t.Run("IgnoreKeys", func(t *testing.T) {
+		expected := `{"slice_name":"Payments by day"}`
+		if got := runNormalizeExport(t, `{"slice_name": "Payments by day", "owners": [1, 2]}`, "owners"); got != expected {
+			t.Errorf("Expected %s, got %s", expected, got)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, content := range []string{"", "slice_name: [unclosed"} {
+			resp := callNormalizeExport(content)
+			if resp.Error == nil {
+				t.Errorf("Expected an error for %q", content)
+			}
+		}
+	})
+}
+
+func runNormalizeExport(t *testing.T, content string, ignoreKeys ...string) string {
+	t.Helper()
+	resp := callNormalizeExport(content, ignoreKeys...)
+	if resp.Error != nil {
+		t.Fatalf("Unexpected error: %s", resp.Error)
+	}
+	return resp.Result.Value().(types.String).ValueString()
+}
+
+func callNormalizeExport(content string, ignoreKeys ...string) function.RunResponse {
+	keyTypes, keys := []attr.Type{}, []attr.Value{}
+	for _, key := range ignoreKeys {
+		keyTypes = append(keyTypes, types.StringType)
+		keys = append(keys, types.StringValue(key))
+	}
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+		types.StringValue(content),
+		types.TupleValueMust(keyTypes, keys),
+	})}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewNormalizeExportFunction().Run(context.Background(), req, &resp)
+	return resp
+}
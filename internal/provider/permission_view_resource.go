@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &permissionViewResource{}
+	_ resource.ResourceWithConfigure   = &permissionViewResource{}
+	_ resource.ResourceWithImportState = &permissionViewResource{}
+)
+
+// NewPermissionViewResource is a helper function to simplify the provider implementation.
+func NewPermissionViewResource() resource.Resource {
+	return &permissionViewResource{}
+}
+
+// permissionViewResource is the resource implementation.
+type permissionViewResource struct {
+	client *client.Client
+}
+
+// permissionViewResourceModel maps the resource schema data.
+type permissionViewResourceModel struct {
+	ID         types.Int64  `tfsdk:"id"`
+	Permission types.String `tfsdk:"permission"`
+	ViewMenu   types.String `tfsdk:"view_menu"`
+}
+
+// Metadata returns the resource type name.
+func (r *permissionViewResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permission_view"
+}
+
+// Schema defines the schema for the resource.
+func (r *permissionViewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Ensures a permission/view menu pair exists in Superset, creating the permission, the view menu and the pair when missing. " +
+			"Useful for plugin permissions that Superset only registers on first access, so superset_role_permissions can grant them right away. " +
+			"Destroying the resource leaves the pair in Superset, as it may be granted to roles or registered by Superset itself.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Numeric identifier of the permission/view menu pair.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"permission": schema.StringAttribute{
+				MarkdownDescription: "The name of the permission, e.g. can_read.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"view_menu": schema.StringAttribute{
+				MarkdownDescription: "The name of the view menu the permission applies to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					viewMenuValidator{},
+				},
+			},
+		},
+	}
+}
+
+// Create creates the missing parts of the permission/view menu pair and sets the initial Terraform state.
+func (r *permissionViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_permission_view", &resp.Diagnostics) {
+		return
+	}
+
+	var plan permissionViewResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := r.client.WithContext(ctx).EnsurePermissionView(plan.Permission.ValueString(), plan.ViewMenu.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating permission view",
+			fmt.Sprintf("Could not create permission %s on view menu %s: %s", plan.Permission.ValueString(), plan.ViewMenu.ValueString(), err),
+		)
+		return
+	}
+	plan.ID = types.Int64Value(id)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, fmt.Sprintf("Ensured permission view ID %d", id))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *permissionViewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Starting Read method")
+	var state permissionViewResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := r.client.WithContext(ctx).GetPermissionIDByNameAndView(state.Permission.ValueString(), state.ViewMenu.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Debug(ctx, fmt.Sprintf("Permission %s on view menu %s not found, removing from state", state.Permission.ValueString(), state.ViewMenu.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading permission view",
+			fmt.Sprintf("Could not read permission %s on view menu %s: %s", state.Permission.ValueString(), state.ViewMenu.ValueString(), err),
+		)
+		return
+	}
+	state.ID = types.Int64Value(id)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called, as every attribute requires a replacement.
+func (r *permissionViewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan permissionViewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the resource from the Terraform state and leaves the pair in Superset.
+func (r *permissionViewResource) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Removing permission view from state, the pair is left in Superset")
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState imports a permission/view menu pair by "permission,view_menu".
+func (r *permissionViewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	permission, viewMenu, ok := strings.Cut(req.ID, ",")
+	if !ok || permission == "" || viewMenu == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form \"permission,view_menu\", got %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("permission"), permission)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("view_menu"), viewMenu)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *permissionViewResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
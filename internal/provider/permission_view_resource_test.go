@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccPermissionViewResource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// The pair only exists once it has been created
+	pairs := []map[string]interface{}{}
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page:0,page_size:5000)",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(200, map[string]interface{}{"count": len(pairs), "result": pairs})
+		})
+
+	// The permission already exists, the plugin view menu does not
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions/?q=(page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "name": "can_read"}]}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/view-menus/?q=(page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 10, "name": "Dashboard"}]}`))
+
+	var createdViewMenu string
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/view-menus/",
+		func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				Name string `json:"name"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				return httpmock.NewStringResponse(400, err.Error()), nil
+			}
+			createdViewMenu = payload.Name
+			return httpmock.NewStringResponse(201, `{"id": 77, "result": {"name": "MapPlugin"}}`), nil
+		})
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/permissions-resources/",
+		func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				PermissionID int64 `json:"permission_id"`
+				ViewMenuID   int64 `json:"view_menu_id"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil || payload.PermissionID != 1 || payload.ViewMenuID != 77 {
+				return httpmock.NewStringResponse(400, `{"message": "unexpected pair"}`), nil
+			}
+			pairs = append(pairs, map[string]interface{}{
+				"id":         501,
+				"permission": map[string]string{"name": "can_read"},
+				"view_menu":  map[string]string{"name": "MapPlugin"},
+			})
+			return httpmock.NewStringResponse(201, `{"id": 501, "result": {}}`), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccPermissionViewResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_permission_view.map_plugin", "id", "501"),
+					resource.TestCheckResourceAttr("superset_permission_view.map_plugin", "permission", "can_read"),
+					resource.TestCheckResourceAttr("superset_permission_view.map_plugin", "view_menu", "MapPlugin"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "superset_permission_view.map_plugin",
+				ImportState:                          true,
+				ImportStateId:                        "can_read,MapPlugin",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "view_menu",
+			},
+		},
+	})
+
+	if createdViewMenu != "MapPlugin" {
+		t.Errorf("expected the MapPlugin view menu to be created, got %q", createdViewMenu)
+	}
+}
+
+const testAccPermissionViewResourceConfig = `
+resource "superset_permission_view" "map_plugin" {
+  permission = "can_read"
+  view_menu  = "MapPlugin"
+}
+`
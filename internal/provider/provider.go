@@ -2,11 +2,22 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"terraform-provider-superset/internal/client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -17,7 +28,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &supersetProvider{}
+	_ provider.Provider                   = &supersetProvider{}
+	_ provider.ProviderWithValidateConfig = &supersetProvider{}
+	_ provider.ProviderWithFunctions      = &supersetProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -34,13 +47,53 @@ type supersetProvider struct {
 	version string
 }
 
+const (
+	// defaultCreateReadRetryAttempts is the number of reads of a just-created object before giving up.
+	defaultCreateReadRetryAttempts = 5
+	// defaultCreateReadRetryDelay is the delay between two reads of a just-created object.
+	defaultCreateReadRetryDelay = 2 * time.Second
+)
+
 // supersetProviderModel maps provider schema data to a Go type.
 type supersetProviderModel struct {
-	Host     types.String `tfsdk:"host"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Host         types.String `tfsdk:"host"`
+	Username     types.String `tfsdk:"username"`
+	Password     types.String `tfsdk:"password"`
+	DisableCache types.Bool   `tfsdk:"disable_cache"`
+	ReadOnly     types.Bool   `tfsdk:"read_only"`
+	RecordHTTP   types.String `tfsdk:"record_http"`
+
+	UserAgentSuffix types.String `tfsdk:"user_agent_suffix"`
+
+	ManagedRolePrefix       types.String `tfsdk:"managed_role_prefix"`
+	AllowBuiltInRoleChanges types.Bool   `tfsdk:"allow_builtin_role_changes"`
+
+	PreflightCheck types.Bool `tfsdk:"preflight_check"`
+
+	SessionKeepalive types.Bool `tfsdk:"session_keepalive"`
+	SecretCommand    types.List `tfsdk:"secret_command"`
+
+	MarkManagedExternally types.Bool   `tfsdk:"mark_managed_externally"`
+	ExternalURL           types.String `tfsdk:"external_url"`
+
+	CreateReadRetryAttempts types.Int64  `tfsdk:"create_read_retry_attempts"`
+	CreateReadRetryDelay    types.String `tfsdk:"create_read_retry_delay"`
+
+	AsyncPollInterval types.String `tfsdk:"async_poll_interval"`
+	AsyncPollTimeout  types.String `tfsdk:"async_poll_timeout"`
+
+	LogLevels types.Map `tfsdk:"log_levels"`
 }
 
+// logSubsystemRolePermissions logs how superset_role_permissions matches and maps permissions.
+const logSubsystemRolePermissions = "resource.role_permissions"
+
+// logSubsystems lists the logging subsystems whose level can be set with log_levels.
+var logSubsystems = []string{client.LogSubsystemHTTP, client.LogSubsystemCache, logSubsystemRolePermissions}
+
+// logLevels lists the levels accepted by log_levels.
+var logLevels = []string{"trace", "debug", "info", "warn", "error", "off"}
+
 // Metadata returns the provider type name.
 func (p *supersetProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "superset"
@@ -50,25 +103,221 @@ func (p *supersetProvider) Metadata(_ context.Context, _ provider.MetadataReques
 // Schema defines the provider-level schema for configuration data.
 func (p *supersetProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Superset provider for managing Superset resources.",
+		MarkdownDescription: "Manages the roles, permissions and database connections of an Apache Superset instance through its REST API, logging in as a user of its database authentication.",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Description: "The URL of the Superset instance. This should include the protocol (http or https) and the hostname or IP address. Example: 'https://superset.example.com'.",
-				Optional:    true,
+				MarkdownDescription: "The URL of the Superset instance. This should include the protocol (http or https) and the hostname or IP address. Example: 'https://superset.example.com'.",
+				Optional:            true,
 			},
 			"username": schema.StringAttribute{
-				Description: "The username to authenticate with Superset. This user should have the necessary permissions to manage resources within Superset.",
-				Optional:    true,
+				MarkdownDescription: "The username to authenticate with Superset. This user should have the necessary permissions to manage resources within Superset.",
+				Optional:            true,
 			},
 			"password": schema.StringAttribute{
-				Description: "The password to authenticate with Superset. This value is sensitive and will not be displayed in logs or state files.",
+				MarkdownDescription: "The password to authenticate with Superset. This value is sensitive and will not be displayed in logs or state files.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"disable_cache": schema.BoolAttribute{
+				MarkdownDescription: "Disable the client-side response caches (ETag and decoded list caches), so every read is served by Superset. " +
+					"Useful when several workspaces manage the same Superset instance concurrently. Defaults to false.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Refuse every create, update and delete operation, so the provider can only read from Superset. " +
+					"Intended for audit pipelines that must never change production even if a plan is applied by mistake. Defaults to false.",
+				Optional: true,
+			},
+			"allow_builtin_role_changes": schema.BoolAttribute{
+				MarkdownDescription: "Allow renaming and deleting the built-in roles (" + strings.Join(client.BuiltInRoleNames, ", ") + ") and replacing or clearing their permissions, " +
+					"which the provider otherwise refuses as e.g. clearing the permissions of Admin locks everyone out of Superset. Defaults to false.",
+				Optional: true,
+			},
+			"managed_role_prefix": schema.StringAttribute{
+				MarkdownDescription: "Prefix the names of the roles managed with `superset_role` must start with, e.g. \"tf-\", " +
+					"so roles outside the namespace, such as the built-in ones, cannot be created, renamed into or imported by mistake. " +
+					"Set `allow_outside_prefix` on a role to manage it anyway.",
+				Optional: true,
+			},
+			"preflight_check": schema.BoolAttribute{
+				MarkdownDescription: "Check when the provider is configured that the user can read and, unless `read_only` is set, write roles, permissions and databases, " +
+					"with one GET per API, and fail with the list of missing permissions instead of failing on the first resource that needs them. Defaults to false.",
+				Optional: true,
+			},
+			"create_read_retry_attempts": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of times a just-created object is read back before the create fails, "+
+					"for Superset deployments whose reads can lag behind writes (e.g. read replicas). Defaults to %d.", defaultCreateReadRetryAttempts),
+				Optional: true,
+			},
+			"create_read_retry_delay": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Delay between two reads of a just-created object, as a Go duration string (e.g. \"500ms\", \"2s\"). Defaults to %s.", defaultCreateReadRetryDelay),
+				Optional:            true,
+			},
+			"async_poll_interval": schema.StringAttribute{
+				MarkdownDescription: "Delay between two checks of an operation Superset runs asynchronously, e.g. a chart data query " +
+					"when global async queries are enabled, as a Go duration string. Defaults to 1s.",
+				Optional: true,
+			},
+			"async_poll_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long an operation Superset runs asynchronously is waited for before failing, as a Go duration string. Defaults to 5m.",
+				Optional:            true,
+			},
+			"session_keepalive": schema.BoolAttribute{
+				MarkdownDescription: "Renew the Superset session with the refresh token issued at login when the access token expires, " +
+					"and retry the rejected request, so long applies (e.g. waiting on a database migration) keep their authentication. Defaults to true.",
+				Optional: true,
+			},
+			"secret_command": schema.ListAttribute{
+				MarkdownDescription: "Command and arguments run to resolve secret references such as `db_pass_ref` on `superset_database`. " +
+					"The reference is appended as the last argument and the command must print the secret on stdout, " +
+					"e.g. a wrapper script around `vault kv get`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"mark_managed_externally": schema.BoolAttribute{
+				MarkdownDescription: "Flag every database the provider creates or updates as managed externally, " +
+					"so Superset shows it as Terraform-managed and locks it against edits in the UI. Defaults to false.",
+				Optional: true,
+			},
+			"external_url": schema.StringAttribute{
+				MarkdownDescription: "URL Superset links externally managed objects to, e.g. the repository holding the Terraform configuration. " +
+					"Only used with mark_managed_externally.",
+				Optional: true,
+			},
+			"record_http": schema.StringAttribute{
+				MarkdownDescription: "Developer option: directory to write a sanitized JSON copy of every request/response pair exchanged with Superset to, " +
+					"for attaching reproductions to bug reports. Credentials, tokens and cookies are redacted. " +
+					"Can also be set with the SUPERSET_RECORD_HTTP environment variable.",
+				Optional: true,
+			},
+			"log_levels": schema.MapAttribute{
+				MarkdownDescription: "Log levels of the provider's logging subsystems, keyed by subsystem, so one noisy area can be silenced while debugging another: " +
+					"`client.http` logs every request sent to Superset at trace level with its status, duration and X-Request-Id and Server-Timing response headers, `client.cache` the hits and misses of the response caches, " +
+					"and `resource.role_permissions` how `superset_role_permissions` matches permissions. " +
+					"Levels are trace, debug, info, warn, error and off. Subsystems not listed log at the provider's level. " +
+					"Terraform drops lines more verbose than TF_LOG_PROVIDER, so a subsystem can be made quieter than the provider but not louder.",
+				ElementType: types.StringType,
 				Optional:    true,
-				Sensitive:   true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the User-Agent sent with every request, which otherwise reads like " +
+					"`terraform-provider-superset/1.2.3 terraform/1.9.0`, so gateways in front of Superset can identify the traffic of a given pipeline. " +
+					"Can also be set with the TF_APPEND_USER_AGENT environment variable.",
+				Optional: true,
 			},
 		},
 	}
 }
 
+// ValidateConfig checks the provider settings that do not depend on the environment, so mistakes are
+// reported at validate time, before the provider logs in to Superset.
+func (p *supersetProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var config supersetProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Host.IsNull() && !config.Host.IsUnknown() {
+		if err := validateHost(config.Host.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("host"), "Invalid Superset API Host", err.Error())
+		}
+	}
+
+	if !config.CreateReadRetryAttempts.IsNull() && !config.CreateReadRetryAttempts.IsUnknown() && config.CreateReadRetryAttempts.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("create_read_retry_attempts"),
+			"Invalid Create Read Retry Attempts",
+			"create_read_retry_attempts must be at least 1.",
+		)
+	}
+
+	if !config.CreateReadRetryDelay.IsNull() && !config.CreateReadRetryDelay.IsUnknown() {
+		if delay, err := time.ParseDuration(config.CreateReadRetryDelay.ValueString()); err != nil || delay < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("create_read_retry_delay"),
+				"Invalid Create Read Retry Delay",
+				fmt.Sprintf("create_read_retry_delay must be a non-negative Go duration string, got %q.", config.CreateReadRetryDelay.ValueString()),
+			)
+		}
+	}
+
+	for name, value := range map[string]types.String{"async_poll_interval": config.AsyncPollInterval, "async_poll_timeout": config.AsyncPollTimeout} {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if duration, err := time.ParseDuration(value.ValueString()); err != nil || duration <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid Async Poll Setting",
+				fmt.Sprintf("%s must be a positive Go duration string, got %q.", name, value.ValueString()),
+			)
+		}
+	}
+
+	for subsystem, level := range knownLogLevels(ctx, config.LogLevels, &resp.Diagnostics) {
+		if !slices.Contains(logSubsystems, subsystem) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("log_levels").AtMapKey(subsystem),
+				"Unknown Logging Subsystem",
+				fmt.Sprintf("Unknown logging subsystem %q, expected one of: %s.", subsystem, strings.Join(logSubsystems, ", ")),
+			)
+		}
+		if !level.IsNull() && !slices.Contains(logLevels, strings.ToLower(level.ValueString())) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("log_levels").AtMapKey(subsystem),
+				"Invalid Log Level",
+				fmt.Sprintf("Invalid log level %q for %s, expected one of: %s.", level.ValueString(), subsystem, strings.Join(logLevels, ", ")),
+			)
+		}
+	}
+
+	var secretCommand []types.String
+	if !config.SecretCommand.IsNull() && !config.SecretCommand.IsUnknown() {
+		resp.Diagnostics.Append(config.SecretCommand.ElementsAs(ctx, &secretCommand, false)...)
+	}
+	if len(secretCommand) > 0 && !secretCommand[0].IsUnknown() && secretCommand[0].ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secret_command"),
+			"Invalid Secret Command",
+			"The first element of secret_command must be the command to run.",
+		)
+	}
+
+	if !config.ExternalURL.IsNull() && !config.MarkManagedExternally.IsUnknown() && !config.MarkManagedExternally.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("external_url"),
+			"External URL Is Ignored",
+			"external_url is only used when mark_managed_externally is true.",
+		)
+	}
+}
+
+// knownLogLevels returns the levels of log_levels that are known. log_levels may be unknown until apply,
+// e.g. when it comes from another resource, and its subsystems then log at the provider's level.
+func knownLogLevels(ctx context.Context, logLevelsMap types.Map, diags *diag.Diagnostics) map[string]types.String {
+	levels := map[string]types.String{}
+	if logLevelsMap.IsNull() || logLevelsMap.IsUnknown() {
+		return levels
+	}
+	diags.Append(logLevelsMap.ElementsAs(ctx, &levels, false)...)
+	for subsystem, level := range levels {
+		if level.IsUnknown() {
+			delete(levels, subsystem)
+		}
+	}
+	return levels
+}
+
+// validateHost checks that host is an absolute http or https URL, as the client appends the API paths to it.
+func validateHost(host string) error {
+	parsed, err := url.Parse(host)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("the Superset API host must be an http:// or https:// URL, e.g. https://superset.example.com, got %q", client.Scrub(host))
+	}
+	return nil
+}
+
 // Configure prepares a Superset API client for data sources and resources.
 func (p *supersetProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	tflog.Info(ctx, "Configuring Superset client")
@@ -109,6 +358,19 @@ func (p *supersetProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	var secretCommand []types.String
+	if !config.SecretCommand.IsNull() && !config.SecretCommand.IsUnknown() {
+		resp.Diagnostics.Append(config.SecretCommand.ElementsAs(ctx, &secretCommand, false)...)
+	}
+	if config.SecretCommand.IsUnknown() || slices.ContainsFunc(secretCommand, types.String.IsUnknown) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secret_command"),
+			"Unknown Secret Command",
+			"The provider cannot resolve secret references as there is an unknown configuration value for secret_command. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -161,34 +423,117 @@ func (p *supersetProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	// The host may come from SUPERSET_HOST, which ValidateConfig cannot see.
+	if host != "" {
+		if err := validateHost(host); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("host"), "Invalid Superset API Host", err.Error())
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Add structured log fields
-	ctx = tflog.SetField(ctx, "superset_host", host)
-	ctx = tflog.SetField(ctx, "superset_username", username)
-	ctx = tflog.SetField(ctx, "superset_password", password)
+	// Add structured log fields. The password is never attached, and the username is
+	// masked before it is set so no log entry can carry it in clear text.
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "superset_username")
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "superset_password")
+	ctx = tflog.MaskAllFieldValuesStrings(ctx, password)
+	ctx = tflog.MaskMessageStrings(ctx, password)
+	ctx = tflog.SetField(ctx, "superset_host", client.Scrub(host))
+	ctx = tflog.SetField(ctx, "superset_username", username)
 
 	tflog.Debug(ctx, "Creating Superset client")
 
+	userAgentSuffix := os.Getenv("TF_APPEND_USER_AGENT")
+	if !config.UserAgentSuffix.IsNull() {
+		userAgentSuffix = config.UserAgentSuffix.ValueString()
+	}
+
 	// Create a new Superset client using the configuration values
-	client, err := client.NewClient(host, username, password)
+	supersetClient, err := client.NewClient(host, username, password, userAgent(p.version, req.TerraformVersion, userAgentSuffix))
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Superset API Client",
-			"An unexpected error occurred when creating the Superset API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"Superset Client Error: "+err.Error(),
-		)
+		summary, detail := loginFailureDiagnostic(err)
+		resp.Diagnostics.AddError(summary, detail+"\n\nSuperset Client Error: "+client.Scrub(err.Error()))
 		return
 	}
 
+	supersetClient.DisableCache = config.DisableCache.ValueBool()
+	supersetClient.ReadOnly = config.ReadOnly.ValueBool()
+	supersetClient.ManagedRolePrefix = config.ManagedRolePrefix.ValueString()
+	supersetClient.AllowBuiltInRoleChanges = config.AllowBuiltInRoleChanges.ValueBool()
+	supersetClient.SessionKeepalive = config.SessionKeepalive.IsNull() || config.SessionKeepalive.ValueBool()
+
+	// The retry and polling settings and secret_command were checked by ValidateConfig.
+	supersetClient.CreateReadRetryAttempts = defaultCreateReadRetryAttempts
+	if !config.CreateReadRetryAttempts.IsNull() {
+		supersetClient.CreateReadRetryAttempts = int(config.CreateReadRetryAttempts.ValueInt64())
+	}
+
+	supersetClient.CreateReadRetryDelay = defaultCreateReadRetryDelay
+	if !config.CreateReadRetryDelay.IsNull() {
+		supersetClient.CreateReadRetryDelay, _ = time.ParseDuration(config.CreateReadRetryDelay.ValueString())
+	}
+
+	if !config.AsyncPollInterval.IsNull() {
+		supersetClient.AsyncPollInterval, _ = time.ParseDuration(config.AsyncPollInterval.ValueString())
+	}
+	if !config.AsyncPollTimeout.IsNull() {
+		supersetClient.AsyncPollTimeout, _ = time.ParseDuration(config.AsyncPollTimeout.ValueString())
+	}
+
+	supersetClient.ManagedExternally = config.MarkManagedExternally.ValueBool()
+	supersetClient.ExternalURL = config.ExternalURL.ValueString()
+
+	for _, arg := range secretCommand {
+		supersetClient.SecretCommand = append(supersetClient.SecretCommand, arg.ValueString())
+	}
+
+	supersetClient.LogLevels = map[string]string{}
+	for subsystem, level := range knownLogLevels(ctx, config.LogLevels, &resp.Diagnostics) {
+		supersetClient.LogLevels[subsystem] = level.ValueString()
+	}
+
+	recordDir := os.Getenv("SUPERSET_RECORD_HTTP")
+	if !config.RecordHTTP.IsNull() {
+		recordDir = config.RecordHTTP.ValueString()
+	}
+	if recordDir != "" {
+		if err := supersetClient.RecordHTTP(recordDir); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("record_http"),
+				"Unable to Record Superset HTTP Exchanges",
+				err.Error(),
+			)
+			return
+		}
+		tflog.Warn(ctx, "Recording Superset HTTP exchanges", map[string]any{"record_http": recordDir})
+	}
+
+	if config.PreflightCheck.ValueBool() {
+		missing, err := supersetClient.WithContext(ctx).Preflight()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("preflight_check"),
+				"Unable to Run Superset Preflight Check",
+				err.Error(),
+			)
+			return
+		}
+		if len(missing) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("preflight_check"),
+				"Insufficient Superset Permissions",
+				"The Superset user of the provider cannot use every API the provider needs:\n\n- "+strings.Join(missing, "\n- ")+"\n\n"+
+					"Grant the missing permissions to one of its roles, e.g. Admin, or unset preflight_check if the listed resources are not used.",
+			)
+			return
+		}
+		tflog.Debug(ctx, "Superset preflight check passed")
+	}
+
 	// Make the Superset client available during DataSource and Resource type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = supersetClient
+	resp.ResourceData = supersetClient
 
 	tflog.Info(ctx, "Configured Superset client", map[string]any{"success": true})
 }
@@ -199,6 +544,24 @@ func (p *supersetProvider) DataSources(_ context.Context) []func() datasource.Da
 		NewRolesDataSource,           // Existing data source
 		NewRolePermissionsDataSource, // New data source
 		NewDatabasesDataSource,       // New databases data source
+		NewViewMenusDataSource,
+		NewRolePresetDataSource,
+		NewChartDataDataSource,
+		NewDashboardPermalinkDataSource,
+		NewSecurityPermissionsDataSource,
+		NewQueriesDataSource,
+		NewRoleExportDataSource,
+		NewDatasetChartsDataSource,
+		NewAPIRequestDataSource,
+		NewCurrentUserDataSource,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *supersetProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewRisonFunction,
+		NewNormalizeExportFunction,
 	}
 }
 
@@ -208,5 +571,179 @@ func (p *supersetProvider) Resources(_ context.Context) []func() resource.Resour
 		NewRoleResource,            // New resource
 		NewRolePermissionsResource, // New resource
 		NewDatabaseResource,        // New resource
+		NewRoleUsersResource,
+		NewDatasetColumnsSyncResource,
+		NewDashboardColorSchemeResource,
+		NewDashboardChartsResource,
+		NewPermissionViewResource,
+		NewRoleImportResource,
+		NewCacheWarmupResource,
+		NewAPIRequestResource,
+	}
+}
+
+// userAgent returns the User-Agent sent to Superset, naming the provider and Terraform versions
+// followed by the configured suffix, e.g. "terraform-provider-superset/1.2.3 terraform/1.9.0 team-dwh".
+func userAgent(providerVersion, terraformVersion, suffix string) string {
+	parts := []string{"terraform-provider-superset/" + providerVersion}
+	if terraformVersion != "" {
+		parts = append(parts, "terraform/"+terraformVersion)
+	}
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		parts = append(parts, suffix)
+	}
+	return strings.Join(parts, " ")
+}
+
+// loginFailureDiagnostic returns the summary and detail of the diagnostic reported when the client
+// cannot be created, telling apart the login failures Superset reports distinctly.
+func loginFailureDiagnostic(err error) (string, string) {
+	switch {
+	case errors.Is(err, client.ErrInvalidCredentials):
+		return "Invalid Superset Credentials",
+			"Superset rejected the username and password. Check the credentials, and that the user is active in Superset: " +
+				"inactive users are rejected with the same error as a wrong password."
+	case errors.Is(err, client.ErrLoginProviderDisabled):
+		return "Superset Database Login Disabled",
+			"The provider logs in with the \"db\" authentication provider, which this Superset instance does not accept " +
+				"(e.g. AUTH_TYPE is set to OAuth or LDAP). Use an instance or user that can authenticate against the Superset database."
+	case errors.Is(err, client.ErrLoginRateLimited):
+		return "Too Many Superset Login Attempts",
+			"Superset refused the login because of too many recent attempts (AUTH_RATE_LIMITED). Wait before running Terraform again."
+	case errors.Is(err, client.ErrServiceUnavailable):
+		return "Superset Unavailable",
+			"Superset kept answering with an error page instead of its API, as it does while it is being upgraded or restarted. " +
+				"Run Terraform again once Superset is back."
+	default:
+		return "Unable to Create Superset API Client",
+			"An unexpected error occurred when creating the Superset API client. " +
+				"If the error is not clear, please contact the provider developers."
+	}
+}
+
+// refuseInReadOnlyMode adds an error and returns true when the provider is configured with
+// read_only, in which case the calling resource must return without changing Superset.
+func refuseInReadOnlyMode(supersetClient *client.Client, operation, resourceType string, diags *diag.Diagnostics) bool {
+	if supersetClient == nil || !supersetClient.ReadOnly {
+		return false
+	}
+
+	diags.AddError(
+		"Provider Is Read-Only",
+		fmt.Sprintf("Refusing to %s %s because the provider is configured with read_only = true. "+
+			"No changes were made to Superset. Remove read_only from the provider configuration to apply changes.", operation, resourceType),
+	)
+	return true
+}
+
+// listDataSourceID returns the identifier of a list data source: a hash of the Superset host, the
+// kind of objects listed and their IDs, so it is stable across reads and only changes with the list.
+func listDataSourceID(host, kind string, ids []int64) types.String {
+	sorted := append([]int64{}, ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s", host, kind)
+	for _, id := range sorted {
+		fmt.Fprintf(hash, "\x00%d", id)
+	}
+	return types.StringValue(hex.EncodeToString(hash.Sum(nil))[:16])
+}
+
+// addRequestError adds the error of a failed create or update to diags. The field errors of a
+// *client.ValidationError are attached to the attribute each field is set from, as listed in
+// attributes, so Terraform points at the offending configuration; anything else is reported as is.
+func addRequestError(diags *diag.Diagnostics, summary, operation string, err error, attributes map[string]path.Path) {
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		diags.AddError(summary, fmt.Sprintf("%s failed: %s", operation, err.Error()))
+		return
+	}
+
+	fields := make([]string, 0, len(validationErr.Fields))
+	for field := range validationErr.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	unmapped := map[string][]string{}
+	for _, field := range fields {
+		messages := validationErr.Fields[field]
+		attribute, ok := attributes[field]
+		if !ok {
+			unmapped[field] = messages
+			continue
+		}
+		diags.AddAttributeError(attribute, summary, fmt.Sprintf("Superset rejected %s: %s", field, strings.Join(messages, " ")))
+	}
+
+	if len(unmapped) > 0 {
+		rest := &client.ValidationError{Action: validationErr.Action, StatusCode: validationErr.StatusCode, Fields: unmapped, RequestID: validationErr.RequestID, ServerTiming: validationErr.ServerTiming}
+		diags.AddError(summary, fmt.Sprintf("%s failed: %s", operation, rest.Error()))
+	}
+}
+
+// removePartiallyCreated deletes an object that was created but whose follow-up steps failed, so a
+// retried apply does not run into a duplicate name. The delete runs even when ctx is done, as a timed
+// out create is the most likely cause; when it fails too, the error names the object left behind.
+func removePartiallyCreated(ctx context.Context, supersetClient *client.Client, diags *diag.Diagnostics, object string, id int64, remove func(*client.Client, int64) error) {
+	err := remove(supersetClient.WithContext(context.WithoutCancel(ctx)), id)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		diags.AddError(
+			"Unable to Remove Partially Created Object",
+			fmt.Sprintf("The %s with ID %d was created but not fully configured, and could not be deleted: %s. "+
+				"Delete it in Superset or import it before applying again.", object, id, err),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Removed partially created %s ID %d", object, id))
+}
+
+// warnIfTruncated adds a warning when Superset reported more objects than a list request returned,
+// as list endpoints cap the page size and would otherwise drop the extra objects silently.
+func warnIfTruncated(diags *diag.Diagnostics, objects string, fetched, count int) {
+	if count <= fetched {
+		return
+	}
+
+	diags.AddWarning(
+		"Superset List Truncated",
+		fmt.Sprintf("Superset reported %d %s but only returned %d, so the others are missing from the result. "+
+			"Please report this issue to the provider developers.", count, objects, fetched),
+	)
+}
+
+// markManagedExternally adds the is_managed_externally and external_url fields to a create or update
+// payload when the provider is configured with mark_managed_externally.
+func markManagedExternally(supersetClient *client.Client, payload map[string]interface{}) {
+	if !supersetClient.ManagedExternally {
+		return
+	}
+
+	payload["is_managed_externally"] = true
+	if supersetClient.ExternalURL != "" {
+		payload["external_url"] = supersetClient.ExternalURL
+	}
+}
+
+// waitForCreated calls read until it stops failing with client.ErrNotFound, at most the configured
+// number of create read attempts, so a just-created object served by a lagging replica does not fail the apply.
+func waitForCreated(ctx context.Context, supersetClient *client.Client, read func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := read()
+		if err == nil || !errors.Is(err, client.ErrNotFound) || attempt >= supersetClient.CreateReadRetryAttempts {
+			return err
+		}
+
+		tflog.Debug(ctx, "Created object is not readable yet, retrying", map[string]interface{}{
+			"attempt": attempt,
+			"delay":   supersetClient.CreateReadRetryDelay.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(supersetClient.CreateReadRetryDelay):
+		}
 	}
 }
@@ -1,10 +1,32 @@
 package provider
 
 import (
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
 	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"terraform-provider-superset/internal/client"
 )
 
 const providerConfig = `
@@ -32,3 +54,927 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("SUPERSET_HOST must be set for acceptance tests")
 	}
 }
+
+func TestAccProviderAliases(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Each Superset instance issues its own token and only accepts it back
+	for host, token := range map[string]string{
+		"http://superset-prod":  "prod-token",
+		"http://superset-stage": "stage-token",
+	} {
+		httpmock.RegisterResponder("POST", host+"/api/v1/security/login",
+			httpmock.NewStringResponder(200, `{"access_token": "`+token+`"}`))
+
+		viewMenus := `{"result": [{"id": 1, "name": "Dashboard"}]}`
+		if host == "http://superset-stage" {
+			viewMenus = `{"result": [{"id": 1, "name": "Dashboard"}, {"id": 2, "name": "SQL Lab"}]}`
+		}
+		expectedAuth := "Bearer " + token
+		httpmock.RegisterResponder("GET", host+"/api/v1/security/view-menus/?q=(page_size:5000)",
+			func(req *http.Request) (*http.Response, error) {
+				if req.Header.Get("Authorization") != expectedAuth {
+					return httpmock.NewStringResponse(401, `{"msg": "Bad Authorization header"}`), nil
+				}
+				resp := httpmock.NewStringResponse(200, viewMenus)
+				resp.Header.Set("ETag", `"same-etag-on-both-instances"`)
+				return resp, nil
+			})
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Each alias reads from its own instance with its own token
+			{
+				Config: testAccProviderAliasesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_view_menus.prod", "view_menus.#", "1"),
+					resource.TestCheckResourceAttr("data.superset_view_menus.stage", "view_menus.#", "2"),
+					resource.TestCheckResourceAttr("data.superset_view_menus.stage", "view_menus.1.name", "SQL Lab"),
+				),
+			},
+		},
+	})
+}
+
+const testAccProviderAliasesConfig = `
+provider "superset" {
+  alias    = "prod"
+  host     = "http://superset-prod"
+  username = "fake-username"
+  password = "fake-password"
+}
+
+provider "superset" {
+  alias    = "stage"
+  host     = "http://superset-stage"
+  username = "fake-username"
+  password = "fake-password"
+}
+
+data "superset_view_menus" "prod" {
+  provider = superset.prod
+}
+
+data "superset_view_menus" "stage" {
+  provider = superset.stage
+}
+`
+
+func TestAccProviderSessionKeepalive(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Login hands out an access token that has already expired, along with a refresh token
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "expired-token", "refresh_token": "refresh-token"}`))
+
+	// The refresh token is exchanged for a fresh access token
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/refresh",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Authorization") != "Bearer refresh-token" {
+				return httpmock.NewStringResponse(401, `{"msg": "Bad Authorization header"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"access_token": "fresh-token"}`), nil
+		})
+
+	// Only the fresh access token is accepted
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/view-menus/?q=(page_size:5000)",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Authorization") != "Bearer fresh-token" {
+				return httpmock.NewStringResponse(401, `{"msg": "Token has expired"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"result": [{"id": 1, "name": "Dashboard"}]}`), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The expired session is renewed and the read retried
+			{
+				Config: providerConfig + `
+data "superset_view_menus" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_view_menus.test", "view_menus.#", "1"),
+					resource.TestCheckResourceAttr("data.superset_view_menus.test", "view_menus.0.name", "Dashboard"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProviderUserAgent(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Both the login and the API requests must identify the provider and carry the suffix
+	userAgent := regexp.MustCompile(`^terraform-provider-superset/test terraform/\S+ team-dwh$`)
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		func(req *http.Request) (*http.Response, error) {
+			if !userAgent.MatchString(req.Header.Get("User-Agent")) {
+				return httpmock.NewStringResponse(400, `{"message": "unexpected user agent"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"access_token": "fake-token"}`), nil
+		})
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/view-menus/?q=(page_size:5000)",
+		func(req *http.Request) (*http.Response, error) {
+			if !userAgent.MatchString(req.Header.Get("User-Agent")) {
+				return httpmock.NewStringResponse(400, `{"message": "unexpected user agent"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"result": [{"id": 1, "name": "Dashboard"}]}`), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "superset" {
+  host              = "http://superset-host"
+  username          = "fake-username"
+  password          = "fake-password"
+  user_agent_suffix = "team-dwh"
+}
+
+data "superset_view_menus" "test" {}
+`,
+				Check: resource.TestCheckResourceAttr("data.superset_view_menus.test", "view_menus.#", "1"),
+			},
+		},
+	})
+}
+
+func TestServiceUnavailableRetry(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	maintenancePage := httpmock.NewStringResponse(503, `<html><body><h1>Superset is being upgraded</h1></body></html>`)
+	maintenancePage.Header.Set("Content-Type", "text/html")
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	supersetClient.UnavailableRetryAttempts = 2
+	supersetClient.UnavailableRetryDelay = time.Millisecond
+
+	t.Run("Recovers", func(t *testing.T) {
+		calls := 0
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/",
+			func(req *http.Request) (*http.Response, error) {
+				calls++
+				if calls < 3 {
+					return maintenancePage, nil
+				}
+				return httpmock.NewStringResponse(200, `{"result": [{"id": 1, "database_name": "examples"}]}`), nil
+			})
+
+		databases, _, err := supersetClient.GetAllDatabases()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(databases) != 1 || calls != 3 {
+			t.Errorf("expected 1 database after 3 requests, got %d after %d", len(databases), calls)
+		}
+	})
+
+	t.Run("StaysUnavailable", func(t *testing.T) {
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/",
+			httpmock.ResponderFromResponse(maintenancePage))
+
+		_, _, err := supersetClient.GetAllDatabases()
+		if !errors.Is(err, client.ErrServiceUnavailable) {
+			t.Errorf("expected ErrServiceUnavailable, got %v", err)
+		}
+	})
+
+	t.Run("JSONErrorsAreNotRetried", func(t *testing.T) {
+		calls := 0
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/",
+			func(req *http.Request) (*http.Response, error) {
+				calls++
+				return httpmock.NewJsonResponse(503, map[string]string{"message": "Database is unavailable"})
+			})
+
+		_, _, err := supersetClient.GetAllDatabases()
+		if err == nil || errors.Is(err, client.ErrServiceUnavailable) || calls != 1 {
+			t.Errorf("expected a single request failing with the status code, got %v after %d requests", err, calls)
+		}
+	})
+}
+
+func TestCreateOutcomeUnknown(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	gatewayTimeout := httpmock.NewStringResponse(504, `<html><body><h1>504 Gateway Time-out</h1></body></html>`)
+	gatewayTimeout.Header.Set("Content-Type", "text/html")
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	supersetClient.UnavailableRetryAttempts = 2
+	supersetClient.UnavailableRetryDelay = time.Millisecond
+
+	// createRole mocks a role create that times out at the gateway, after creating the role when applied is set.
+	createRole := func(applied bool) *int {
+		posts := 0
+		created := false
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+			func(req *http.Request) (*http.Response, error) {
+				if created {
+					return httpmock.NewStringResponse(200, `{"result": [{"id": 5, "name": "Antifraud"}]}`), nil
+				}
+				return httpmock.NewStringResponse(200, `{"result": []}`), nil
+			})
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/",
+			func(req *http.Request) (*http.Response, error) {
+				posts++
+				if posts == 1 {
+					created = applied
+					return gatewayTimeout, nil
+				}
+				created = true
+				return httpmock.NewStringResponse(201, `{"id": 5, "name": "Antifraud"}`), nil
+			})
+		return &posts
+	}
+
+	t.Run("AppliedIsNotSentAgain", func(t *testing.T) {
+		posts := createRole(true)
+		id, created, err := supersetClient.CreateRole("Antifraud")
+		if err != nil || id != 5 {
+			t.Fatalf("expected the created role 5, got %d, %v", id, err)
+		}
+		if created {
+			t.Error("expected a role found after an unknown outcome not to be reported as created")
+		}
+		if *posts != 1 {
+			t.Errorf("expected a single create, got %d", *posts)
+		}
+	})
+
+	t.Run("NotAppliedIsSentAgain", func(t *testing.T) {
+		posts := createRole(false)
+		id, created, err := supersetClient.CreateRole("Antifraud")
+		if err != nil || id != 5 {
+			t.Fatalf("expected the created role 5, got %d, %v", id, err)
+		}
+		if !created {
+			t.Error("expected the posted role to be reported as created")
+		}
+		if *posts != 2 {
+			t.Errorf("expected the create to be sent again once, got %d creates", *posts)
+		}
+	})
+
+	t.Run("RawCreateIsNotRetried", func(t *testing.T) {
+		posts := 0
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/tag/",
+			func(req *http.Request) (*http.Response, error) {
+				posts++
+				return gatewayTimeout, nil
+			})
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+			httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+
+		_, err := supersetClient.APIRequest("POST", "/api/v1/tag/", []byte(`{"name": "team:dwh"}`))
+		if !errors.Is(err, client.ErrOutcomeUnknown) || posts != 1 {
+			t.Errorf("expected a single request failing with ErrOutcomeUnknown, got %v after %d requests", err, posts)
+		}
+	})
+}
+
+func TestUpdateRolePermissionsInBatches(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/7",
+		httpmock.NewStringResponder(200, `{"result": {"id": 7, "name": "DWH-Analysts"}}`))
+
+	granted := []int64{1, 2, 3}
+	var requests [][]int64
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/7/permissions/",
+		func(req *http.Request) (*http.Response, error) {
+			var permissions []client.Permission
+			for _, id := range granted {
+				permissions = append(permissions, client.Permission{ID: id})
+			}
+			return httpmock.NewJsonResponse(200, map[string]interface{}{"result": permissions})
+		})
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/7/permissions",
+		func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				PermissionViewMenuIDs []int64 `json:"permission_view_menu_ids"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return httpmock.NewStringResponse(400, err.Error()), nil
+			}
+			granted = body.PermissionViewMenuIDs
+			requests = append(requests, granted)
+			return httpmock.NewStringResponse(200, `{}`), nil
+		})
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1 is revoked first, then 4 to 8 are added two at a time
+	if err := supersetClient.UpdateRolePermissionsInBatches(7, []int64{2, 3, 4, 5, 6, 7, 8}, 2); err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]int64{{2, 3}, {2, 3, 4, 5}, {2, 3, 4, 5, 6, 7}, {2, 3, 4, 5, 6, 7, 8}}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("expected the requests %v, got %v", expected, requests)
+	}
+
+	// Permissions Superset did not apply fail the verification
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/7/permissions",
+		httpmock.NewStringResponder(200, `{}`))
+	err = supersetClient.UpdateRolePermissionsInBatches(7, []int64{9}, 10)
+	if err == nil || !strings.Contains(err.Error(), "1 not granted, 7 not revoked") {
+		t.Errorf("expected the verification to fail, got %v", err)
+	}
+}
+
+func TestAsyncChartData(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/chart/7?q=(columns:!(id,query_context))",
+		httpmock.NewStringResponder(200, `{"result": {"query_context": "{\"queries\": []}"}}`))
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/chart/data",
+		httpmock.NewStringResponder(202, `{"channel_id": "c1", "job_id": "j1", "status": "pending", "result_url": "/api/v1/chart/data/qc-7"}`))
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	supersetClient.AsyncPollInterval = time.Millisecond
+
+	t.Run("Completes", func(t *testing.T) {
+		polls := 0
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/chart/data/qc-7",
+			func(req *http.Request) (*http.Response, error) {
+				polls++
+				if polls < 3 {
+					return httpmock.NewStringResponse(404, `{"message": "Cached data not found"}`), nil
+				}
+				return httpmock.NewStringResponse(200, `{"result": [{"rowcount": 1, "colnames": ["total"], "data": [{"total": 42}]}]}`), nil
+			})
+
+		data, err := supersetClient.GetChartData(7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.RowCount != 1 || polls != 3 {
+			t.Errorf("expected 1 row after 3 polls, got %d after %d", data.RowCount, polls)
+		}
+	})
+
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/chart/data/qc-7",
+		httpmock.NewStringResponder(404, `{"message": "Cached data not found"}`))
+
+	t.Run("TimesOut", func(t *testing.T) {
+		slow := *supersetClient
+		slow.AsyncPollTimeout = 20 * time.Millisecond
+
+		_, err := slow.GetChartData(7)
+		if !errors.Is(err, client.ErrPollTimeout) {
+			t.Errorf("expected ErrPollTimeout, got %v", err)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		_, err := supersetClient.WithContext(ctx).GetChartData(7)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the poll to stop when the context is cancelled, got %v", err)
+		}
+	})
+}
+
+func TestKnownLogLevels(t *testing.T) {
+	cases := map[string]struct {
+		levels   types.Map
+		expected map[string]types.String
+	}{
+		"Null":    {levels: types.MapNull(types.StringType), expected: map[string]types.String{}},
+		"Unknown": {levels: types.MapUnknown(types.StringType), expected: map[string]types.String{}},
+		"UnknownLevel": {
+			levels: types.MapValueMust(types.StringType, map[string]attr.Value{
+				client.LogSubsystemHTTP:  types.StringValue("warn"),
+				client.LogSubsystemCache: types.StringUnknown(),
+			}),
+			expected: map[string]types.String{client.LogSubsystemHTTP: types.StringValue("warn")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			levels := knownLogLevels(context.Background(), tc.levels, &diags)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if !reflect.DeepEqual(levels, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, levels)
+			}
+		})
+	}
+}
+
+func TestLogLevels(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/1/schemas/",
+		httpmock.NewStringResponder(200, `{"result": ["public"]}`))
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		levels   map[string]string
+		expected []string
+	}{
+		"Default":   {nil, []string{client.LogSubsystemHTTP, client.LogSubsystemCache}},
+		"CacheOff":  {map[string]string{client.LogSubsystemCache: "off"}, []string{client.LogSubsystemHTTP}},
+		"HTTPWarn":  {map[string]string{client.LogSubsystemHTTP: "WARN"}, []string{client.LogSubsystemCache}},
+		"BothError": {map[string]string{client.LogSubsystemHTTP: "error", client.LogSubsystemCache: "error"}, nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var output bytes.Buffer
+			supersetClient.LogLevels = tc.levels
+
+			if _, err := supersetClient.WithContext(tflogtest.RootLogger(context.Background(), &output)).GetDatabaseSchemasByID(1); err != nil {
+				t.Fatal(err)
+			}
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			logged := map[string]bool{}
+			for _, entry := range entries {
+				if module, ok := entry["@module"].(string); ok {
+					logged[strings.TrimPrefix(module, "provider.")] = true
+				}
+			}
+			for _, subsystem := range []string{client.LogSubsystemHTTP, client.LogSubsystemCache} {
+				if expected := slices.Contains(tc.expected, subsystem); logged[subsystem] != expected {
+					t.Errorf("expected %s to log: %t, got modules %v", subsystem, expected, logged)
+				}
+			}
+		})
+	}
+}
+
+func TestResponseRequestID(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+	rejected := httpmock.NewStringResponse(422, `{"message": {"name": ["Name must be unique."]}}`)
+	rejected.Header.Set("X-Request-Id", "4f1c2a9e")
+	rejected.Header.Set("Server-Timing", "app;dur=42")
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/tag/", httpmock.ResponderFromResponse(rejected))
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	_, err = supersetClient.WithContext(tflogtest.RootLogger(context.Background(), &output)).APIRequest("POST", "/api/v1/tag/", []byte(`{"name": "team:dwh"}`))
+
+	// The request ID and server timing end the error, so the failure can be found in the server logs
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.RequestID != "4f1c2a9e" || validationErr.ServerTiming != "app;dur=42" ||
+		!strings.HasSuffix(err.Error(), "(request ID: 4f1c2a9e, server timing: app;dur=42)") {
+		t.Errorf("expected a validation error with the request ID and server timing, got %v", err)
+	}
+
+	// The troubleshooting headers are logged with the request
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logged := false
+	for _, entry := range entries {
+		if entry["path"] == "/api/v1/tag/" {
+			logged = entry["request_id"] == "4f1c2a9e" && entry["server_timing"] == "app;dur=42"
+		}
+	}
+	if !logged {
+		t.Errorf("expected the request to be logged with its request ID and server timing, got %v", entries)
+	}
+}
+
+func TestGetDatabaseIDByName(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+	httpmock.RegisterResponder("GET", `=~^http://superset-host/api/v1/database/\?q=.*value:%27examples%27`,
+		httpmock.NewStringResponder(200, `{"count": 2, "result": [{"id": 3, "database_name": "examples"}, {"id": 9, "database_name": "Examples"}]}`))
+	httpmock.RegisterResponder("GET", `=~^http://superset-host/api/v1/database/\?q=.*value:%27dwh%27`,
+		httpmock.NewStringResponder(200, `{"count": 2, "result": [{"id": 12, "database_name": "dwh"}, {"id": 4, "database_name": "dwh"}]}`))
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Matches differing in case only are not the same name
+	id, err := supersetClient.GetDatabaseIDByName("examples")
+	if err != nil || id != 3 {
+		t.Errorf("expected ID 3, got %d, %v", id, err)
+	}
+
+	// Several matches are reported with their IDs instead of picking one
+	_, err = supersetClient.GetDatabaseIDByName("dwh")
+	if !errors.Is(err, client.ErrAmbiguousName) {
+		t.Fatalf("expected an ambiguous name error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "IDs 4, 12") {
+		t.Errorf("expected the error to list the candidate IDs, got %q", err)
+	}
+}
+
+func TestBuiltInRoleProtection(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(200, `{"result": {"id": 1, "name": "Admin"}}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/2",
+		httpmock.NewStringResponder(200, `{"result": {"id": 2, "name": "DWH-DB-Connect"}}`))
+	httpmock.RegisterResponder("POST", `=~^http://superset-host/api/v1/security/roles/\d+/permissions`,
+		httpmock.NewStringResponder(200, `{}`))
+	httpmock.RegisterResponder("DELETE", `=~^http://superset-host/api/v1/security/roles/\d+`,
+		httpmock.NewStringResponder(204, ""))
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The built-in roles are refused without sending the change
+	if err := supersetClient.ClearRolePermissions(1); !errors.Is(err, client.ErrBuiltInRole) {
+		t.Errorf("expected clearing the permissions of Admin to be refused, got %v", err)
+	}
+	if err := supersetClient.DeleteRole(1); !errors.Is(err, client.ErrBuiltInRole) {
+		t.Errorf("expected deleting Admin to be refused, got %v", err)
+	}
+	if err := supersetClient.UpdateRole(1, "Administrators"); !errors.Is(err, client.ErrBuiltInRole) {
+		t.Errorf("expected renaming Admin to be refused, got %v", err)
+	}
+	if err := supersetClient.UpdateRolePermissions(1, nil); !errors.Is(err, client.ErrBuiltInRole) {
+		t.Errorf("expected replacing the permissions of Admin to be refused, got %v", err)
+	}
+	if err := supersetClient.UpdateRolePermissionsInBatches(1, nil, 10); !errors.Is(err, client.ErrBuiltInRole) {
+		t.Errorf("expected replacing the permissions of Admin in batches to be refused, got %v", err)
+	}
+	calls := httpmock.GetCallCountInfo()
+	if calls["POST =~^http://superset-host/api/v1/security/roles/\\d+/permissions"] != 0 || calls["DELETE =~^http://superset-host/api/v1/security/roles/\\d+"] != 0 {
+		t.Errorf("expected no change to be sent for Admin, got %v", calls)
+	}
+
+	// Other roles are changed as usual
+	if err := supersetClient.ClearRolePermissions(2); err != nil {
+		t.Errorf("expected clearing the permissions of a custom role to succeed, got %v", err)
+	}
+
+	// The override lets the built-in roles be changed
+	supersetClient.AllowBuiltInRoleChanges = true
+	if err := supersetClient.DeleteRole(1); err != nil {
+		t.Errorf("expected deleting Admin to succeed with the override, got %v", err)
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/_info?q=(keys:!(permissions))",
+		httpmock.NewStringResponder(200, `{"permissions": ["can_read", "can_write"]}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources/_info?q=(keys:!(permissions))",
+		httpmock.NewStringResponder(403, `{"message": "Forbidden"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/_info?q=(keys:!(permissions))",
+		httpmock.NewStringResponder(200, `{"permissions": ["can_read"]}`))
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Forbidden APIs and missing write permissions are both listed
+	missing, err := supersetClient.Preflight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 2 || !strings.Contains(missing[0], "/api/v1/security/permissions-resources/ is forbidden") || !strings.Contains(missing[1], "needs can_write on Database") {
+		t.Errorf("expected the permissions API and writing databases to be missing, got %q", missing)
+	}
+
+	// A read-only provider does not need write permissions
+	supersetClient.ReadOnly = true
+	missing, err = supersetClient.Preflight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 1 {
+		t.Errorf("expected only the permissions API to be missing, got %q", missing)
+	}
+
+	// A turned off security API names the setting to turn on
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/_info?q=(keys:!(permissions))",
+		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/?q=(page_size:1)",
+		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
+	missing, err = supersetClient.Preflight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) == 0 || !strings.Contains(missing[0], "set FAB_ADD_SECURITY_API = True") {
+		t.Errorf("expected the security API to be reported as turned off, got %q", missing)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	cases := []struct {
+		providerVersion, terraformVersion, suffix, expected string
+	}{
+		{"1.2.3", "1.9.0", "", "terraform-provider-superset/1.2.3 terraform/1.9.0"},
+		{"1.2.3", "1.9.0", " team-dwh ", "terraform-provider-superset/1.2.3 terraform/1.9.0 team-dwh"},
+		{"dev", "", "ci", "terraform-provider-superset/dev ci"},
+	}
+
+	for _, c := range cases {
+		if got := userAgent(c.providerVersion, c.terraformVersion, c.suffix); got != c.expected {
+			t.Errorf("userAgent(%q, %q, %q): expected %q, got %q", c.providerVersion, c.terraformVersion, c.suffix, c.expected, got)
+		}
+	}
+}
+
+func TestListDataSourceID(t *testing.T) {
+	id := listDataSourceID("http://superset-host", "roles", []int64{3, 1, 2})
+	if len(id.ValueString()) != 16 {
+		t.Errorf("expected a 16 character ID, got %q", id.ValueString())
+	}
+	if reordered := listDataSourceID("http://superset-host", "roles", []int64{1, 2, 3}); !id.Equal(reordered) {
+		t.Errorf("expected the ID not to depend on the order of the list, got %s and %s", id, reordered)
+	}
+	for _, other := range []types.String{
+		listDataSourceID("http://superset-other", "roles", []int64{1, 2, 3}),
+		listDataSourceID("http://superset-host", "view_menus", []int64{1, 2, 3}),
+		listDataSourceID("http://superset-host", "roles", []int64{1, 2}),
+	} {
+		if id.Equal(other) {
+			t.Errorf("expected a different host, kind or list to change the ID %s", id)
+		}
+	}
+}
+
+func TestWarnIfTruncated(t *testing.T) {
+	var diags diag.Diagnostics
+	warnIfTruncated(&diags, "roles", 20, 20)
+	warnIfTruncated(&diags, "roles", 20, 0)
+	if diags.WarningsCount() != 0 {
+		t.Errorf("expected no warning for a complete list, got %v", diags)
+	}
+
+	warnIfTruncated(&diags, "databases", 20, 134)
+	if diags.WarningsCount() != 1 || !strings.Contains(diags[0].Detail(), "reported 134 databases but only returned 20") {
+		t.Errorf("expected a truncation warning, got %v", diags)
+	}
+}
+
+func TestAddRequestError(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "fake-csrf-token"}`))
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/database/",
+		httpmock.NewStringResponder(422, `{"message": {"database_name": ["A database with the same name already exists."], "sqlalchemy_uri": ["Invalid connection string"]}}`))
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = supersetClient.CreateDatabase(map[string]interface{}{"database_name": "examples"})
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.StatusCode != 422 {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	var diags diag.Diagnostics
+	addRequestError(&diags, "Unable to Create Superset Database Connection", "CreateDatabase", err, databaseRequestFields)
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected an attribute error and a general error, got %v", diags)
+	}
+
+	attributeDiag, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !attributeDiag.Path().Equal(path.Root("connection_name")) || !strings.Contains(attributeDiag.Detail(), "same name already exists") {
+		t.Errorf("expected the database_name error on connection_name, got %v", diags[0])
+	}
+	if _, ok := diags[1].(diag.DiagnosticWithPath); ok || !strings.Contains(diags[1].Detail(), "sqlalchemy_uri: Invalid connection string") ||
+		strings.Contains(diags[1].Detail(), "database_name") {
+		t.Errorf("expected only the sqlalchemy_uri error without a path, got %v", diags[1])
+	}
+
+	diags = nil
+	addRequestError(&diags, "Unable to Create Superset Role", "CreateRole", errors.New("connection refused"), roleRequestFields)
+	if diags.ErrorsCount() != 1 || diags[0].Detail() != "CreateRole failed: connection refused" {
+		t.Errorf("expected other errors to be reported as is, got %v", diags)
+	}
+}
+
+func TestMarkManagedExternally(t *testing.T) {
+	payload := map[string]interface{}{"database_name": "examples"}
+	markManagedExternally(&client.Client{}, payload)
+	if _, ok := payload["is_managed_externally"]; ok {
+		t.Errorf("expected the payload to be left untouched, got %v", payload)
+	}
+
+	markManagedExternally(&client.Client{ManagedExternally: true, ExternalURL: "https://git.example.com/superset"}, payload)
+	if payload["is_managed_externally"] != true || payload["external_url"] != "https://git.example.com/superset" {
+		t.Errorf("expected the payload to be marked as managed externally, got %v", payload)
+	}
+}
+
+func TestAccProviderLoginFailures(t *testing.T) {
+	cases := map[string]struct {
+		status int
+		body   string
+		error  string
+	}{
+		"WrongCredentials": {401, `{"message": "Not authorized"}`, "Invalid Superset Credentials"},
+		"ProviderDisabled": {400, `{"message": "Provider db not supported"}`, "Superset Database Login Disabled"},
+		"RateLimited":      {429, `<html>Too Many Requests</html>`, "Too Many Superset Login Attempts"},
+		"Unexpected":       {500, `{"message": "Fatal error"}`, "Unable to Create Superset API Client"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			// Mock the Superset API login response
+			httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+				httpmock.NewStringResponder(tc.status, tc.body))
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig + `
+data "superset_view_menus" "test" {}
+`,
+						ExpectError: regexp.MustCompile(tc.error),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccProviderValidateConfig(t *testing.T) {
+	cases := map[string]struct {
+		settings string
+		error    string
+	}{
+		"HostWithoutScheme":  {`host = "superset-host"`, "Invalid Superset API Host"},
+		"RetryAttempts":      {"host = \"http://superset-host\"\n  create_read_retry_attempts = 0", "Invalid Create Read Retry Attempts"},
+		"RetryDelay":         {"host = \"http://superset-host\"\n  create_read_retry_delay = \"soon\"", "Invalid Create Read Retry Delay"},
+		"EmptySecretCommand": {"host = \"http://superset-host\"\n  secret_command = [\"\"]", "Invalid Secret Command"},
+		"LogSubsystem":       {"host = \"http://superset-host\"\n  log_levels = { \"client.sql\" = \"debug\" }", "Unknown Logging Subsystem"},
+		"LogLevel":           {"host = \"http://superset-host\"\n  log_levels = { \"client.http\" = \"verbose\" }", "Invalid Log Level"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: `
+provider "superset" {
+  ` + tc.settings + `
+  username = "fake-username"
+  password = "fake-password"
+}
+
+data "superset_view_menus" "test" {}
+`,
+						ExpectError: regexp.MustCompile(tc.error),
+					},
+				},
+			})
+
+			// The configuration is rejected before the provider logs in.
+			if calls := httpmock.GetTotalCallCount(); calls != 0 {
+				t.Errorf("expected no request to Superset, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestProviderValidateConfigUnknown(t *testing.T) {
+	ctx := context.Background()
+	p := &supersetProvider{}
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	// Settings known only at apply are left for Configure, instead of failing to read the configuration
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range configType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["host"] = tftypes.NewValue(tftypes.String, "http://superset-host")
+	values["secret_command"] = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue)
+	values["log_levels"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tftypes.UnknownValue)
+
+	resp := &provider.ValidateConfigResponse{}
+	p.ValidateConfig(ctx, provider.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, values)},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("expected unknown settings to be accepted, got %v", resp.Diagnostics)
+	}
+}
+
+func TestValidateHost(t *testing.T) {
+	for _, host := range []string{"http://superset-host", "https://superset.example.com/", "https://superset.example.com:8443/superset"} {
+		if err := validateHost(host); err != nil {
+			t.Errorf("validateHost(%q): unexpected error %v", host, err)
+		}
+	}
+	for _, host := range []string{"superset-host", "superset.example.com:8088", "ftp://superset-host", "https://", "https://user:s3cret@"} {
+		err := validateHost(host)
+		if err == nil {
+			t.Errorf("validateHost(%q): expected an error", host)
+		} else if strings.Contains(err.Error(), "s3cret") {
+			t.Errorf("validateHost(%q): the error must not leak credentials, got %v", host, err)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// defaultQueriesLimit is the number of queries returned when limit is not set.
+const defaultQueriesLimit = 100
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &queriesDataSource{}
+	_ datasource.DataSourceWithConfigure = &queriesDataSource{}
+)
+
+// NewQueriesDataSource is a helper function to simplify the provider implementation.
+func NewQueriesDataSource() datasource.DataSource {
+	return &queriesDataSource{}
+}
+
+// queriesDataSource is the data source implementation.
+type queriesDataSource struct {
+	client *client.Client
+}
+
+// queriesDataSourceModel maps the data source schema data.
+type queriesDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Username      types.String `tfsdk:"username"`
+	DatabaseID    types.Int64  `tfsdk:"database_id"`
+	Status        types.String `tfsdk:"status"`
+	StartedAfter  types.String `tfsdk:"started_after"`
+	StartedBefore types.String `tfsdk:"started_before"`
+	Limit         types.Int64  `tfsdk:"limit"`
+	Queries       []queryModel `tfsdk:"queries"`
+}
+
+// queryModel maps a SQL Lab query.
+type queryModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	Status       types.String `tfsdk:"status"`
+	SQL          types.String `tfsdk:"sql"`
+	ExecutedSQL  types.String `tfsdk:"executed_sql"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	Schema       types.String `tfsdk:"schema"`
+	TabName      types.String `tfsdk:"tab_name"`
+	Rows         types.Int64  `tfsdk:"rows"`
+	UserID       types.Int64  `tfsdk:"user_id"`
+	UserName     types.String `tfsdk:"user_name"`
+	StartTime    types.String `tfsdk:"start_time"`
+	EndTime      types.String `tfsdk:"end_time"`
+}
+
+// Metadata returns the data source type name.
+func (d *queriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_queries"
+}
+
+// Schema defines the schema for the data source.
+func (d *queriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the SQL Lab query history from Superset, most recent first, for governance and capacity reporting.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the data source, derived from the Superset host and the IDs of the listed queries, so it only changes when they do.",
+				Computed:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Only return queries run by the user with this username.",
+				Optional:            true,
+			},
+			"database_id": schema.Int64Attribute{
+				MarkdownDescription: "Only return queries run against the database with this ID.",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only return queries with this status, e.g. success, failed, running, stopped or timed_out.",
+				Optional:            true,
+			},
+			"started_after": schema.StringAttribute{
+				MarkdownDescription: "Only return queries started after this time, as an RFC 3339 timestamp (e.g. \"2024-06-01T00:00:00Z\").",
+				Optional:            true,
+			},
+			"started_before": schema.StringAttribute{
+				MarkdownDescription: "Only return queries started before this time, as an RFC 3339 timestamp.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of queries returned. Defaults to %d.", defaultQueriesLimit),
+				Optional:            true,
+			},
+			"queries": schema.ListNestedAttribute{
+				MarkdownDescription: "List of queries.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Numeric identifier of the query.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Status of the query.",
+							Computed:            true,
+						},
+						"sql": schema.StringAttribute{
+							MarkdownDescription: "SQL as written by the user.",
+							Computed:            true,
+						},
+						"executed_sql": schema.StringAttribute{
+							MarkdownDescription: "SQL as executed by Superset, after limits were applied.",
+							Computed:            true,
+						},
+						"database_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the database the query ran against.",
+							Computed:            true,
+						},
+						"schema": schema.StringAttribute{
+							MarkdownDescription: "Schema the query ran in.",
+							Computed:            true,
+						},
+						"tab_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the SQL Lab tab the query was run from.",
+							Computed:            true,
+						},
+						"rows": schema.Int64Attribute{
+							MarkdownDescription: "Number of rows returned by the query.",
+							Computed:            true,
+						},
+						"user_id": schema.Int64Attribute{
+							MarkdownDescription: "Numeric identifier of the user who ran the query.",
+							Computed:            true,
+						},
+						"user_name": schema.StringAttribute{
+							MarkdownDescription: "Full name of the user who ran the query.",
+							Computed:            true,
+						},
+						"start_time": schema.StringAttribute{
+							MarkdownDescription: "Time the query started, as an RFC 3339 timestamp.",
+							Computed:            true,
+						},
+						"end_time": schema.StringAttribute{
+							MarkdownDescription: "Time the query ended, as an RFC 3339 timestamp. Empty while the query runs.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *queriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state queriesDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	supersetClient := d.client.WithContext(ctx)
+
+	filter := client.QueryFilter{
+		DatabaseID: state.DatabaseID.ValueInt64(),
+		Status:     state.Status.ValueString(),
+		Limit:      defaultQueriesLimit,
+	}
+	if !state.Limit.IsNull() {
+		if state.Limit.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("limit"),
+				"Invalid Limit",
+				fmt.Sprintf("limit must be at least 1, got %d.", state.Limit.ValueInt64()),
+			)
+			return
+		}
+		filter.Limit = int(state.Limit.ValueInt64())
+	}
+	for attribute, bound := range map[string]struct {
+		value  types.String
+		target *time.Time
+	}{
+		"started_after":  {state.StartedAfter, &filter.StartedAfter},
+		"started_before": {state.StartedBefore, &filter.StartedBefore},
+	} {
+		if bound.value.IsNull() {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, bound.value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Invalid Timestamp",
+				fmt.Sprintf("%s must be an RFC 3339 timestamp such as \"2024-06-01T00:00:00Z\": %s", attribute, err),
+			)
+			return
+		}
+		*bound.target = parsed
+	}
+
+	if !state.Username.IsNull() {
+		userIDs, err := supersetClient.GetUserIDsByUsername([]string{state.Username.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Find Superset User",
+				err.Error(),
+			)
+			return
+		}
+		filter.UserID = userIDs[state.Username.ValueString()]
+	}
+
+	queries, err := supersetClient.FetchQueries(filter)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Superset Queries",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Queries = []queryModel{}
+	var ids []int64
+	for _, query := range queries {
+		ids = append(ids, query.ID)
+		state.Queries = append(state.Queries, queryModel{
+			ID:           types.Int64Value(query.ID),
+			Status:       types.StringValue(query.Status),
+			SQL:          types.StringValue(query.SQL),
+			ExecutedSQL:  types.StringValue(query.ExecutedSQL),
+			DatabaseName: types.StringValue(query.Database.DatabaseName),
+			Schema:       types.StringValue(query.Schema),
+			TabName:      types.StringValue(query.TabName),
+			Rows:         types.Int64Value(query.Rows),
+			UserID:       types.Int64Value(query.User.ID),
+			UserName:     types.StringValue(strings.TrimSpace(query.User.FirstName + " " + query.User.LastName)),
+			StartTime:    types.StringValue(formatQueryTime(query.StartTime)),
+			EndTime:      types.StringValue(formatQueryTime(query.EndTime)),
+		})
+	}
+	state.ID = listDataSourceID(d.client.Host, "queries", ids)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// formatQueryTime formats a query time, in milliseconds since the epoch, as an RFC 3339 timestamp.
+func formatQueryTime(milliseconds float64) string {
+	if milliseconds == 0 {
+		return ""
+	}
+	return time.UnixMilli(int64(milliseconds)).UTC().Format(time.RFC3339)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *queriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccQueriesDataSource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for listing users
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/users/?q=(columns:!(id,username),page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "username": "admin"}, {"id": 3, "username": "alice"}]}`))
+
+	// Mock the Superset API response for the filtered query history
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/query/?q=(filters:!((col:user,opr:rel_o_m,value:3),(col:database,opr:rel_o_m,value:2),(col:status,opr:eq,value:'success'),(col:start_time,opr:gt,value:1717200000000)),order_column:start_time,order_direction:desc,page_size:10)",
+		httpmock.NewStringResponder(200, `{
+			"count": 1,
+			"result": [
+				{
+					"id": 41,
+					"status": "success",
+					"sql": "SELECT country, count(*) FROM orders GROUP BY 1",
+					"executed_sql": "SELECT country, count(*) FROM orders GROUP BY 1\nLIMIT 1001",
+					"schema": "sales",
+					"tab_name": "Untitled Query 1",
+					"rows": 3,
+					"start_time": 1717243200000.0,
+					"end_time": 1717243201500.0,
+					"database": {"database_name": "Trino"},
+					"user": {"id": 3, "first_name": "Alice", "last_name": "Smith"}
+				}
+			]
+		}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccQueriesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_queries.test", "queries.#", "1"),
+					resource.TestCheckResourceAttr("data.superset_queries.test", "queries.0.id", "41"),
+					resource.TestCheckResourceAttr("data.superset_queries.test", "queries.0.database_name", "Trino"),
+					resource.TestCheckResourceAttr("data.superset_queries.test", "queries.0.rows", "3"),
+					resource.TestCheckResourceAttr("data.superset_queries.test", "queries.0.user_name", "Alice Smith"),
+					resource.TestCheckResourceAttr("data.superset_queries.test", "queries.0.start_time", "2024-06-01T12:00:00Z"),
+					resource.TestCheckResourceAttr("data.superset_queries.test", "queries.0.end_time", "2024-06-01T12:00:01Z"),
+				),
+			},
+		},
+	})
+}
+
+const testAccQueriesDataSourceConfig = `
+data "superset_queries" "test" {
+  username      = "alice"
+  database_id   = 2
+  status        = "success"
+  started_after = "2024-06-01T00:00:00Z"
+  limit         = 10
+}
+`
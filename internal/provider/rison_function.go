@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &risonFunction{}
+)
+
+// NewRisonFunction is a helper function to simplify the provider implementation.
+func NewRisonFunction() function.Function {
+	return &risonFunction{}
+}
+
+// risonFunction is the function implementation.
+type risonFunction struct{}
+
+// Metadata returns the function name.
+func (f *risonFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rison"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *risonFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Encode a value as Rison",
+		MarkdownDescription: "Encodes a value as Rison, the notation of the q= parameter of Superset's REST API, " +
+			"e.g. {filters = [{col = \"database_name\", opr = \"eq\", value = \"examples\"}]} becomes " +
+			"(filters:!((col:database_name,opr:eq,value:examples))). Object keys are sorted, and strings are " +
+			"only quoted when they are not valid Rison identifiers. The result is not URL encoded.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "value",
+				MarkdownDescription: "The value to encode: an object, a list, a string, a number, a bool or null.",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run encodes the argument as Rison.
+func (f *risonFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	encoded, err := risonValue(ctx, value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, encoded)
+}
+
+// risonValue encodes a Terraform value as Rison.
+func risonValue(ctx context.Context, value types.Dynamic) (string, error) {
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return client.Rison(nil)
+	}
+
+	raw, err := value.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		return "", err
+	}
+	decoded, err := terraformValueToGo(raw)
+	if err != nil {
+		return "", err
+	}
+	return client.Rison(decoded)
+}
+
+// terraformValueToGo converts a Terraform value to the Go types client.Rison accepts.
+// Lists, sets and tuples become slices, and maps and objects become maps.
+func terraformValueToGo(value tftypes.Value) (interface{}, error) {
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("cannot encode an unknown value")
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	typ := value.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		err := value.As(&n)
+		return n, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, 0, len(elements))
+		for _, element := range elements {
+			item, err := terraformValueToGo(element)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return nil, err
+		}
+		object := make(map[string]interface{}, len(attributes))
+		for key, attribute := range attributes {
+			item, err := terraformValueToGo(attribute)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = item
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("cannot encode a value of type %s", typ)
+	}
+}
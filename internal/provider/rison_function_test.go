@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRisonFunction(t *testing.T) {
+	ctx := context.Background()
+	filter := types.ObjectValueMust(
+		map[string]attr.Type{"col": types.StringType, "opr": types.StringType, "value": types.StringType},
+		map[string]attr.Value{"col": types.StringValue("database_name"), "opr": types.StringValue("eq"), "value": types.StringValue("examples")},
+	)
+
+	tests := []struct {
+		name     string
+		value    attr.Value
+		expected string
+	}{
+		{
+			name: "Filters",
+			value: types.ObjectValueMust(
+				map[string]attr.Type{"filters": types.TupleType{ElemTypes: []attr.Type{filter.Type(ctx)}}, "page_size": types.NumberType},
+				map[string]attr.Value{
+					"filters":   types.TupleValueMust([]attr.Type{filter.Type(ctx)}, []attr.Value{filter}),
+					"page_size": types.NumberValue(big.NewFloat(100)),
+				},
+			),
+			expected: "(filters:!((col:database_name,opr:eq,value:examples)),page_size:100)",
+		},
+		{
+			name:     "QuotedString",
+			value:    types.StringValue("it's 2 o'clock!"),
+			expected: "'it!'s 2 o!'clock!!'",
+		},
+		{
+			name:     "StringStartingWithDigit",
+			value:    types.StringValue("2024"),
+			expected: "'2024'",
+		},
+		{
+			name:     "EmptyString",
+			value:    types.StringValue(""),
+			expected: "''",
+		},
+		{
+			name:     "Fraction",
+			value:    types.NumberValue(big.NewFloat(-1.5)),
+			expected: "-1.5",
+		},
+		{
+			name:     "Exponent",
+			value:    types.NumberValue(big.NewFloat(1.5e30)),
+			expected: "1.5e30",
+		},
+		{
+			name: "ListOfBools",
+			value: types.ListValueMust(types.BoolType, []attr.Value{
+				types.BoolValue(true), types.BoolValue(false), types.BoolNull(),
+			}),
+			expected: "!(!t,!f,!n)",
+		},
+		{
+			name:     "EmptyMap",
+			value:    types.MapValueMust(types.StringType, map[string]attr.Value{}),
+			expected: "()",
+		},
+		{
+			name:     "Null",
+			value:    nil,
+			expected: "!n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argument := types.DynamicNull()
+			if tt.value != nil {
+				argument = types.DynamicValue(tt.value)
+			}
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{argument})}
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+			NewRisonFunction().Run(ctx, req, &resp)
+			if resp.Error != nil {
+				t.Fatalf("Unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value().(types.String).ValueString(); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRisonFunctionUnknown(t *testing.T) {
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+		types.DynamicValue(types.ListValueMust(types.StringType, []attr.Value{types.StringUnknown()})),
+	})}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+	NewRisonFunction().Run(context.Background(), req, &resp)
+	if resp.Error == nil {
+		t.Fatal("Expected an error for an unknown value")
+	}
+}
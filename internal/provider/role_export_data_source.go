@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &roleExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &roleExportDataSource{}
+)
+
+// NewRoleExportDataSource is a helper function to simplify the provider implementation.
+func NewRoleExportDataSource() datasource.DataSource {
+	return &roleExportDataSource{}
+}
+
+// roleExportDataSource is the data source implementation.
+type roleExportDataSource struct {
+	client *client.Client
+}
+
+// roleExportDataSourceModel maps the data source schema data.
+type roleExportDataSourceModel struct {
+	RoleNames []types.String `tfsdk:"role_names"`
+	JSON      types.String   `tfsdk:"json"`
+}
+
+// fabRole is a role in the JSON format written by `superset fab export-roles` and read by `superset fab import-roles`.
+type fabRole struct {
+	Name        string          `json:"name"`
+	Permissions []fabPermission `json:"permissions"`
+}
+
+// fabPermission is a permission/view menu pair of a fabRole.
+type fabPermission struct {
+	Permission fabName `json:"permission"`
+	ViewMenu   fabName `json:"view_menu"`
+}
+
+// fabName is the named object FAB nests permissions and view menus in.
+type fabName struct {
+	Name string `json:"name"`
+}
+
+// Metadata returns the data source type name.
+func (d *roleExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *roleExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exports roles and their permissions in the JSON format of `superset fab export-roles`, " +
+			"which `superset fab import-roles` and the superset_role_import resource accept.",
+		Attributes: map[string]schema.Attribute{
+			"role_names": schema.ListAttribute{
+				MarkdownDescription: "Names of the roles to export.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The exported roles, with their permissions sorted by view menu and permission name.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *roleExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state roleExportDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var names []string
+	for _, name := range state.RoleNames {
+		names = append(names, name.ValueString())
+	}
+
+	roles, err := exportRoles(d.client.WithContext(ctx), names)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Export Superset Roles",
+			err.Error(),
+		)
+		return
+	}
+
+	document, err := marshalFABRoles(roles)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Export Superset Roles",
+			err.Error(),
+		)
+		return
+	}
+	state.JSON = types.StringValue(document)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// exportRoles reads the named roles and their permissions, in the order of names.
+func exportRoles(supersetClient *client.Client, names []string) ([]fabRole, error) {
+	roles := []fabRole{}
+	for _, name := range names {
+		roleID, err := supersetClient.GetRoleIDByName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions, err := supersetClient.GetRolePermissions(roleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the permissions of role %s: %w", name, err)
+		}
+
+		role := fabRole{Name: name, Permissions: []fabPermission{}}
+		for _, perm := range permissions {
+			role.Permissions = append(role.Permissions, fabPermission{
+				Permission: fabName{Name: perm.PermissionName},
+				ViewMenu:   fabName{Name: perm.ViewMenuName},
+			})
+		}
+		roles = append(roles, canonicalFABRole(role))
+	}
+	return roles, nil
+}
+
+// canonicalFABRole returns a copy of the role with its permissions deduplicated and sorted by
+// view menu, then permission name, so documents can be compared and exported stably.
+func canonicalFABRole(role fabRole) fabRole {
+	seen := map[fabPermission]bool{}
+	permissions := []fabPermission{}
+	for _, perm := range role.Permissions {
+		if !seen[perm] {
+			seen[perm] = true
+			permissions = append(permissions, perm)
+		}
+	}
+	sort.Slice(permissions, func(i, j int) bool {
+		if permissions[i].ViewMenu.Name != permissions[j].ViewMenu.Name {
+			return permissions[i].ViewMenu.Name < permissions[j].ViewMenu.Name
+		}
+		return permissions[i].Permission.Name < permissions[j].Permission.Name
+	})
+	return fabRole{Name: role.Name, Permissions: permissions}
+}
+
+// marshalFABRoles encodes roles the way `superset fab export-roles` indents them.
+func marshalFABRoles(roles []fabRole) (string, error) {
+	document, err := json.MarshalIndent(roles, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(document), nil
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *roleExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccRoleExportDataSource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for getting role ID by name
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "name": "DWH-DB-Connect"}, {"id": 2, "name": "Empty"}]}`))
+
+	// Mock the Superset API responses for getting role permissions, out of order
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1/permissions/",
+		httpmock.NewStringResponder(200, `{
+			"result": [
+				{"id": 241, "permission_name": "schema_access", "view_menu_name": "[Trino].[devoriginationzestorage]"},
+				{"id": 240, "permission_name": "database_access", "view_menu_name": "[Trino].(id:34)"}
+			]
+		}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/2/permissions/",
+		httpmock.NewStringResponder(200, `{"result": []}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccRoleExportDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_role_export.example", "json", `[
+  {
+    "name": "DWH-DB-Connect",
+    "permissions": [
+      {
+        "permission": {
+          "name": "database_access"
+        },
+        "view_menu": {
+          "name": "[Trino].(id:34)"
+        }
+      },
+      {
+        "permission": {
+          "name": "schema_access"
+        },
+        "view_menu": {
+          "name": "[Trino].[devoriginationzestorage]"
+        }
+      }
+    ]
+  },
+  {
+    "name": "Empty",
+    "permissions": []
+  }
+]`),
+				),
+			},
+		},
+	})
+}
+
+const testAccRoleExportDataSourceConfig = `
+data "superset_role_export" "example" {
+  role_names = ["DWH-DB-Connect", "Empty"]
+}
+`
@@ -0,0 +1,407 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &roleImportResource{}
+	_ resource.ResourceWithConfigure      = &roleImportResource{}
+	_ resource.ResourceWithImportState    = &roleImportResource{}
+	_ resource.ResourceWithModifyPlan     = &roleImportResource{}
+	_ resource.ResourceWithValidateConfig = &roleImportResource{}
+)
+
+// NewRoleImportResource is a helper function to simplify the provider implementation.
+func NewRoleImportResource() resource.Resource {
+	return &roleImportResource{}
+}
+
+// roleImportResource is the resource implementation.
+type roleImportResource struct {
+	client *client.Client
+}
+
+// roleImportResourceModel maps the resource schema data.
+type roleImportResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	JSON    types.String `tfsdk:"json"`
+	RoleIDs types.Map    `tfsdk:"role_ids"`
+}
+
+// Metadata returns the resource type name.
+func (r *roleImportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_import"
+}
+
+// Schema defines the schema for the resource.
+func (r *roleImportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Pushes roles described in the JSON format of `superset fab export-roles` to Superset, " +
+			"to bring roles managed with `superset fab import-roles` under Terraform. " +
+			"Missing roles, permissions and view menus are created, and each role is given exactly the permissions of the document. " +
+			"Roles dropped from the document or left behind by destroying the resource are kept in Superset. " +
+			"Documents naming a built-in role, unless allow_builtin_role_changes is set, or a role outside the managed_role_prefix of the provider are refused before any role is changed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the resource, the comma separated names of the roles.",
+				Computed:            true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The roles to push, as a JSON list of objects with a `name` and `permissions`, " +
+					"each permission being an object like `{\"permission\": {\"name\": \"can_read\"}, \"view_menu\": {\"name\": \"Dashboard\"}}`.",
+				Required: true,
+			},
+			"role_ids": schema.MapAttribute{
+				MarkdownDescription: "Numeric identifiers of the roles, by name.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the document is a list of uniquely named roles.
+func (r *roleImportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var document types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("json"), &document)...)
+	if resp.Diagnostics.HasError() || document.IsNull() || document.IsUnknown() {
+		return
+	}
+
+	if _, err := parseFABRoles(document.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("json"),
+			"Invalid Roles Document",
+			err.Error(),
+		)
+	}
+}
+
+// ModifyPlan refuses documents naming roles the provider must not push, so they are reported at plan time.
+func (r *roleImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var document types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("json"), &document)...)
+	if resp.Diagnostics.HasError() || document.IsUnknown() {
+		return
+	}
+
+	// Invalid documents are reported by ValidateConfig.
+	if roles, err := parseFABRoles(document.ValueString()); err == nil {
+		checkRoleNames(r.client, roles, &resp.Diagnostics)
+	}
+}
+
+// Create pushes the roles and sets the initial Terraform state.
+func (r *roleImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_role_import", &resp.Diagnostics) {
+		return
+	}
+
+	var plan roleImportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.pushRoles(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, fmt.Sprintf("Pushed roles %s", plan.ID.ValueString()))
+}
+
+// Read refreshes the Terraform state with the latest data. The document is kept as written
+// while the roles match it, and replaced by their export when they drifted.
+func (r *roleImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Starting Read method")
+	var state roleImportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	declared, err := parseFABRoles(state.JSON.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Roles Document", err.Error())
+		return
+	}
+
+	supersetClient := r.client.WithContext(ctx)
+	var names []string
+	for _, role := range declared {
+		_, err := supersetClient.GetRoleIDByName(role.Name)
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Debug(ctx, fmt.Sprintf("Role %s not found", role.Name))
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading roles", fmt.Sprintf("Could not read role %s: %s", role.Name, err))
+			return
+		}
+		names = append(names, role.Name)
+	}
+	if len(names) == 0 {
+		tflog.Debug(ctx, "None of the roles exist anymore, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	remote, err := exportRoles(supersetClient, names)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading roles", err.Error())
+		return
+	}
+
+	if !sameFABRoles(declared, remote) {
+		document, err := marshalFABRoles(remote)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading roles", err.Error())
+			return
+		}
+		state.JSON = types.StringValue(document)
+	}
+
+	r.setRoleIDs(ctx, supersetClient, &state, remote, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update pushes the roles of the new document and sets the updated Terraform state on success.
+func (r *roleImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Starting Update method")
+	if refuseInReadOnlyMode(r.client, "update", "superset_role_import", &resp.Diagnostics) {
+		return
+	}
+
+	var plan roleImportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.pushRoles(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state and leaves the roles in Superset.
+func (r *roleImportResource) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Removing imported roles from state, the roles are left in Superset")
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState imports roles by their comma separated names. The document is filled in from
+// Superset by the following read.
+func (r *roleImportResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	roles := []fabRole{}
+	for _, name := range strings.Split(req.ID, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			roles = append(roles, fabRole{Name: name, Permissions: []fabPermission{}})
+		}
+	}
+	if len(roles) == 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form \"role_name,other_role_name\", got %q", req.ID),
+		)
+		return
+	}
+
+	document, err := marshalFABRoles(roles)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("json"), document)...)
+}
+
+// pushRoles creates the missing roles, permissions and view menus of the planned document and
+// replaces the permissions of each role with those of the document.
+func (r *roleImportResource) pushRoles(ctx context.Context, plan *roleImportResourceModel, diags *diag.Diagnostics) {
+	roles, err := parseFABRoles(plan.JSON.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Roles Document", err.Error())
+		return
+	}
+
+	// Every role is checked before the first one is changed, so a refused role cannot leave the
+	// roles before it pushed without a state recording them.
+	checkRoleNames(r.client, roles, diags)
+	if diags.HasError() {
+		return
+	}
+
+	supersetClient := r.client.WithContext(ctx)
+	for _, role := range roles {
+		roleID, _, err := supersetClient.CreateRole(role.Name)
+		if err != nil {
+			diags.AddError("Error creating role", fmt.Sprintf("Could not create role %s: %s", role.Name, err))
+			return
+		}
+
+		permissionIDs := []int64{}
+		for _, perm := range role.Permissions {
+			id, err := supersetClient.EnsurePermissionView(perm.Permission.Name, perm.ViewMenu.Name)
+			if err != nil {
+				diags.AddError(
+					"Error creating permission view",
+					fmt.Sprintf("Could not create permission %s on view menu %s for role %s: %s", perm.Permission.Name, perm.ViewMenu.Name, role.Name, err),
+				)
+				return
+			}
+			permissionIDs = append(permissionIDs, id)
+		}
+
+		if err := supersetClient.UpdateRolePermissions(roleID, permissionIDs); err != nil {
+			diags.AddError("Error updating role permissions", fmt.Sprintf("Could not update the permissions of role %s: %s", role.Name, err))
+			return
+		}
+	}
+
+	r.setRoleIDs(ctx, supersetClient, plan, roles, diags)
+}
+
+// checkRoleNames adds an error for each role of the document the provider must not push: the built-in
+// roles unless allow_builtin_role_changes is set, and the roles outside the managed_role_prefix.
+func checkRoleNames(c *client.Client, roles []fabRole, diags *diag.Diagnostics) {
+	for _, role := range roles {
+		if slices.Contains(client.BuiltInRoleNames, role.Name) && !c.AllowBuiltInRoleChanges {
+			diags.AddAttributeError(
+				path.Root("json"),
+				"Built-In Role",
+				fmt.Sprintf("%q is a %s of Superset, whose permissions the document would replace. "+
+					"Remove it from the document, or set allow_builtin_role_changes in the provider configuration to push it anyway.", role.Name, client.ErrBuiltInRole),
+			)
+		}
+		if c.ManagedRolePrefix != "" && !strings.HasPrefix(role.Name, c.ManagedRolePrefix) {
+			diags.AddAttributeError(
+				path.Root("json"),
+				"Role Outside Managed Prefix",
+				fmt.Sprintf("Role %q does not start with %q, the managed_role_prefix of the provider. "+
+					"Rename the role, or manage it with superset_role and allow_outside_prefix = true.", role.Name, c.ManagedRolePrefix),
+			)
+		}
+	}
+}
+
+// setRoleIDs sets the identifiers of the resource and of its roles.
+func (r *roleImportResource) setRoleIDs(ctx context.Context, supersetClient *client.Client, model *roleImportResourceModel, roles []fabRole, diags *diag.Diagnostics) {
+	var names []string
+	roleIDs := map[string]int64{}
+	for _, role := range roles {
+		roleID, err := supersetClient.GetRoleIDByName(role.Name)
+		if err != nil {
+			diags.AddError("Error reading roles", fmt.Sprintf("Could not read role %s: %s", role.Name, err))
+			return
+		}
+		names = append(names, role.Name)
+		roleIDs[role.Name] = roleID
+	}
+
+	ids, d := types.MapValueFrom(ctx, types.Int64Type, roleIDs)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue(strings.Join(names, ","))
+	model.RoleIDs = ids
+}
+
+// parseFABRoles decodes a roles document and checks that every role has a unique name and
+// every permission a permission and view menu name.
+func parseFABRoles(document string) ([]fabRole, error) {
+	var roles []fabRole
+	if err := json.Unmarshal([]byte(document), &roles); err != nil {
+		return nil, fmt.Errorf("the roles document is not a JSON list of roles: %w", err)
+	}
+	if len(roles) == 0 {
+		return nil, errors.New("the roles document holds no role")
+	}
+
+	seen := map[string]bool{}
+	for _, role := range roles {
+		if role.Name == "" {
+			return nil, errors.New("every role of the document must have a name")
+		}
+		if seen[role.Name] {
+			return nil, fmt.Errorf("role %s appears more than once in the document", role.Name)
+		}
+		seen[role.Name] = true
+
+		for _, perm := range role.Permissions {
+			if perm.Permission.Name == "" || perm.ViewMenu.Name == "" {
+				return nil, fmt.Errorf("every permission of role %s must name a permission and a view menu", role.Name)
+			}
+		}
+	}
+	return roles, nil
+}
+
+// sameFABRoles reports whether two documents hold the same roles with the same permissions,
+// regardless of their order.
+func sameFABRoles(a, b []fabRole) bool {
+	canonical := func(roles []fabRole) string {
+		sorted := make([]fabRole, 0, len(roles))
+		for _, role := range roles {
+			sorted = append(sorted, canonicalFABRole(role))
+		}
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+		document, _ := json.Marshal(sorted)
+		return string(document)
+	}
+	return canonical(a) == canonical(b)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *roleImportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
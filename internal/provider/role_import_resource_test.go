@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+	"terraform-provider-superset/internal/client"
+)
+
+func TestAccRoleImportResource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// The role only exists once it has been created
+	roles := []map[string]interface{}{{"id": 1, "name": "Admin"}}
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(200, map[string]interface{}{"result": roles})
+		})
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/",
+		func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				Name string `json:"name"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				return httpmock.NewStringResponse(400, err.Error()), nil
+			}
+			roles = append(roles, map[string]interface{}{"id": 7, "name": payload.Name})
+			return httpmock.NewStringResponse(201, `{"id": 7, "result": {}}`), nil
+		})
+
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/7",
+		httpmock.NewStringResponder(200, `{"result": {"id": 7, "name": "Migrated"}}`))
+
+	// Both permission/view menu pairs already exist
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page:0,page_size:5000)",
+		httpmock.NewStringResponder(200, `{
+			"count": 2,
+			"result": [
+				{"id": 11, "permission": {"name": "can_read"}, "view_menu": {"name": "Dashboard"}},
+				{"id": 12, "permission": {"name": "can_read"}, "view_menu": {"name": "Chart"}}
+			]
+		}`))
+
+	// The role keeps the pairs last granted to it
+	granted := []map[string]interface{}{}
+	pairs := map[float64]map[string]interface{}{
+		11: {"id": 11, "permission_name": "can_read", "view_menu_name": "Dashboard"},
+		12: {"id": 12, "permission_name": "can_read", "view_menu_name": "Chart"},
+	}
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/7/permissions",
+		func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				IDs []float64 `json:"permission_view_menu_ids"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				return httpmock.NewStringResponse(400, err.Error()), nil
+			}
+			granted = []map[string]interface{}{}
+			for _, id := range payload.IDs {
+				granted = append(granted, pairs[id])
+			}
+			return httpmock.NewStringResponse(200, `{}`), nil
+		})
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/7/permissions/",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(200, map[string]interface{}{"result": granted})
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccRoleImportResourceConfig(`["Dashboard"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_role_import.migrated", "id", "Migrated"),
+					resource.TestCheckResourceAttr("superset_role_import.migrated", "role_ids.Migrated", "7"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccRoleImportResourceConfig(`["Dashboard", "Chart"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(*terraform.State) error {
+						if len(granted) != 2 {
+							return fmt.Errorf("expected 2 permissions granted to the role, got %d", len(granted))
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing, the document is read back in the export format
+			{
+				ResourceName:            "superset_role_import.migrated",
+				ImportState:             true,
+				ImportStateId:           "Migrated",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"json"},
+			},
+		},
+	})
+}
+
+func TestAccRoleImportResourceBuiltInRole(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "name": "Admin"}]}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(200, `{"result": {"id": 1, "name": "Admin"}}`))
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/1/permissions",
+		httpmock.NewStringResponder(200, `{}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A document stripping the permissions of Admin is refused
+			{
+				Config: providerConfig + `
+resource "superset_role_import" "admin" {
+  json = jsonencode([{ name = "Admin", permissions = [] }])
+}
+`,
+				ExpectError: regexp.MustCompile(`built-in role`),
+			},
+		},
+	})
+
+	if calls := httpmock.GetCallCountInfo()["POST http://superset-host/api/v1/security/roles/1/permissions"]; calls != 0 {
+		t.Errorf("expected the permissions of Admin to be left alone, got %d updates", calls)
+	}
+}
+
+func testAccRoleImportResourceConfig(viewMenus string) string {
+	return `
+resource "superset_role_import" "migrated" {
+  json = jsonencode([
+    {
+      name = "Migrated"
+      permissions = [
+        for view_menu in ` + viewMenus + ` : {
+          permission = { name = "can_read" }
+          view_menu  = { name = view_menu }
+        }
+      ]
+    },
+  ])
+}
+`
+}
+
+func TestCheckRoleNames(t *testing.T) {
+	roles := []fabRole{{Name: "Admin"}, {Name: "tf-Analysts"}, {Name: "Analysts"}}
+
+	var diags diag.Diagnostics
+	checkRoleNames(&client.Client{}, roles, &diags)
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Built-In Role" {
+		t.Errorf("expected only Admin to be refused, got %v", diags)
+	}
+
+	diags = nil
+	checkRoleNames(&client.Client{ManagedRolePrefix: "tf-", AllowBuiltInRoleChanges: true}, roles, &diags)
+	if diags.ErrorsCount() != 2 {
+		t.Errorf("expected Admin and Analysts to be refused as outside the prefix, got %v", diags)
+	}
+}
+
+func TestSameFABRoles(t *testing.T) {
+	declared, err := parseFABRoles(`[{"name": "Migrated", "permissions": [
+		{"permission": {"name": "can_read"}, "view_menu": {"name": "Dashboard"}},
+		{"permission": {"name": "can_read"}, "view_menu": {"name": "Chart"}}
+	]}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reordered := []fabRole{{Name: "Migrated", Permissions: []fabPermission{
+		{Permission: fabName{Name: "can_read"}, ViewMenu: fabName{Name: "Chart"}},
+		{Permission: fabName{Name: "can_read"}, ViewMenu: fabName{Name: "Dashboard"}},
+	}}}
+	if !sameFABRoles(declared, reordered) {
+		t.Error("expected documents differing only by permission order to be the same")
+	}
+
+	if sameFABRoles(declared, []fabRole{{Name: "Migrated", Permissions: reordered[0].Permissions[:1]}}) {
+		t.Error("expected a missing permission to be reported as drift")
+	}
+
+	for _, document := range []string{`{}`, `[]`, `[{"permissions": []}]`, `[{"name": "A"}, {"name": "A"}]`, `[{"name": "A", "permissions": [{"permission": {"name": "can_read"}}]}]`} {
+		if _, err := parseFABRoles(document); err == nil {
+			t.Errorf("expected %s to be rejected", document)
+		}
+	}
+}
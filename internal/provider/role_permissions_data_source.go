@@ -28,6 +28,7 @@ type rolePermissionsDataSource struct {
 
 // rolePermissionsDataSourceModel maps the data source schema data.
 type rolePermissionsDataSourceModel struct {
+	ID          types.String      `tfsdk:"id"`
 	RoleName    types.String      `tfsdk:"role_name"`
 	Permissions []permissionModel `tfsdk:"permissions"`
 }
@@ -47,28 +48,32 @@ func (d *rolePermissionsDataSource) Metadata(_ context.Context, req datasource.M
 // Schema defines the schema for the data source.
 func (d *rolePermissionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Fetches the permissions for a role from Superset.",
+		MarkdownDescription: "Fetches the permissions for a role from Superset.",
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the data source, derived from the Superset host and the IDs of the role and its permissions, so it only changes when they do.",
+				Computed:            true,
+			},
 			"role_name": schema.StringAttribute{
-				Description: "Name of the role.",
-				Required:    true,
+				MarkdownDescription: "Name of the role.",
+				Required:            true,
 			},
 			"permissions": schema.ListNestedAttribute{
-				Description: "List of permissions.",
-				Computed:    true,
+				MarkdownDescription: "List of permissions.",
+				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.Int64Attribute{
-							Description: "Numeric identifier of the permission.",
-							Computed:    true,
+							MarkdownDescription: "Numeric identifier of the permission.",
+							Computed:            true,
 						},
 						"permission_name": schema.StringAttribute{
-							Description: "Name of the permission.",
-							Computed:    true,
+							MarkdownDescription: "Name of the permission.",
+							Computed:            true,
 						},
 						"view_menu_name": schema.StringAttribute{
-							Description: "Name of the view menu associated with the permission.",
-							Computed:    true,
+							MarkdownDescription: "Name of the view menu associated with the permission.",
+							Computed:            true,
 						},
 					},
 				},
@@ -113,6 +118,12 @@ func (d *rolePermissionsDataSource) Read(ctx context.Context, req datasource.Rea
 		})
 	}
 
+	ids := []int64{roleID}
+	for _, perm := range permissions {
+		ids = append(ids, perm.ID)
+	}
+	state.ID = listDataSourceID(d.client.Host, "role_permissions", ids)
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
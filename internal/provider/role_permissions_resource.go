@@ -2,9 +2,13 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 
 	"strconv"
+	"strings"
 	"terraform-provider-superset/internal/client"
 	"time"
 
@@ -13,15 +17,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &rolePermissionsResource{}
-	_ resource.ResourceWithConfigure   = &rolePermissionsResource{}
-	_ resource.ResourceWithImportState = &rolePermissionsResource{}
+	_ resource.Resource                   = &rolePermissionsResource{}
+	_ resource.ResourceWithConfigure      = &rolePermissionsResource{}
+	_ resource.ResourceWithImportState    = &rolePermissionsResource{}
+	_ resource.ResourceWithUpgradeState   = &rolePermissionsResource{}
+	_ resource.ResourceWithModifyPlan     = &rolePermissionsResource{}
+	_ resource.ResourceWithValidateConfig = &rolePermissionsResource{}
 )
 
 // NewRolePermissionsResource is a helper function to simplify the provider implementation.
@@ -36,10 +44,25 @@ type rolePermissionsResource struct {
 
 // rolePermissionsResourceModel maps the resource schema data.
 type rolePermissionsResourceModel struct {
-	ID                  types.String              `tfsdk:"id"`
-	RoleName            types.String              `tfsdk:"role_name"`
-	ResourcePermissions []resourcePermissionModel `tfsdk:"resource_permissions"`
-	LastUpdated         types.String              `tfsdk:"last_updated"`
+	ID                     types.String              `tfsdk:"id"`
+	RoleName               types.String              `tfsdk:"role_name"`
+	ResourcePermissions    []resourcePermissionModel `tfsdk:"resource_permissions"`
+	DatabaseAccess         []databaseAccessModel     `tfsdk:"database_access"`
+	IgnoreMissing          types.Bool                `tfsdk:"ignore_missing"`
+	IgnorePermissions      []ignorePermissionModel   `tfsdk:"ignore_permissions"`
+	AllowBuiltinRole       types.Bool                `tfsdk:"allow_builtin_role"`
+	BatchSize              types.Int64               `tfsdk:"batch_size"`
+	KeepUnknownPermissions types.Bool                `tfsdk:"keep_unknown_permissions"`
+	UnknownPermissions     []resourcePermissionModel `tfsdk:"unknown_permissions"`
+	LastUpdated            types.String              `tfsdk:"last_updated"`
+	Timeouts               *timeoutsModel            `tfsdk:"timeouts"`
+}
+
+// databaseAccessModel maps a database_access grant resolved from a database ID.
+type databaseAccessModel struct {
+	DatabaseID types.Int64  `tfsdk:"database_id"`
+	ID         types.Int64  `tfsdk:"id"`
+	ViewMenu   types.String `tfsdk:"view_menu"`
 }
 
 type resourcePermissionModel struct {
@@ -56,67 +79,244 @@ func (r *rolePermissionsResource) Metadata(_ context.Context, req resource.Metad
 // Schema defines the schema for the resource.
 func (r *rolePermissionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages the permissions associated with a role in Superset.",
+		MarkdownDescription: "Manages the permissions associated with a role in Superset.",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "The unique identifier for the role permissions resource.",
-				Computed:    true,
+				MarkdownDescription: "The unique identifier for the role permissions resource.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"last_updated": schema.StringAttribute{
-				Description: "The timestamp of the last update to the role permissions.",
-				Computed:    true,
+				MarkdownDescription: "The timestamp of the last update to the role permissions.",
+				Computed:            true,
 			},
 			"role_name": schema.StringAttribute{
-				Description: "The name of the role to which the permissions are assigned.",
-				Required:    true,
+				MarkdownDescription: "The name of the role to which the permissions are assigned.",
+				Required:            true,
+			},
+			"resource_permissions": schema.SetNestedAttribute{
+				MarkdownDescription: "Permissions to grant to the role, as pairs of a permission and a view menu, e.g. `{ permission = \"can_read\", view_menu = \"Dashboard\" }`. Permissions granted in Superset but not listed here, in `database_access` or in `ignore_permissions` are revoked, unless `keep_unknown_permissions` is set.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "The unique identifier of the permission. Null for permissions skipped by ignore_missing.",
+							Computed:            true,
+						},
+						"permission": schema.StringAttribute{
+							MarkdownDescription: "The name of the permission.",
+							Required:            true,
+						},
+						"view_menu": schema.StringAttribute{
+							MarkdownDescription: "The name of the view menu associated with the permission.",
+							Required:            true,
+							Validators: []validator.String{
+								viewMenuValidator{},
+							},
+						},
+					},
+				},
+			},
+			"database_access": schema.SetNestedAttribute{
+				MarkdownDescription: "A list of databases to grant database_access on. The view menu is resolved from the database ID when the permissions are applied, " +
+					"so a superset_database created in the same apply can be referenced directly.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"database_id": schema.Int64Attribute{
+							MarkdownDescription: "Numeric identifier of the database, e.g. superset_database.example.id.",
+							Required:            true,
+						},
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "The unique identifier of the resolved database_access permission.",
+							Computed:            true,
+						},
+						"view_menu": schema.StringAttribute{
+							MarkdownDescription: "The resolved view menu of the database, in the form [database_name].(id:N).",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"ignore_missing": schema.BoolAttribute{
+				MarkdownDescription: "Skip resource_permissions that do not exist in Superset instead of failing, and warn about them. " +
+					"Lets one configuration target several Superset versions, where some permissions (e.g. can_export on Chart) may not exist. Defaults to false.",
+				Optional: true,
 			},
-			"resource_permissions": schema.ListNestedAttribute{
-				Description: "A list of permissions associated with the role.",
-				Required:    true,
+			"allow_builtin_role": schema.BoolAttribute{
+				MarkdownDescription: "Allow managing the permissions of a built-in role (" + strings.Join(client.BuiltInRoleNames, ", ") + "), " +
+					"e.g. to keep those of Public minimal. Built-in roles are refused otherwise, as a mistake can lock users out of Superset, " +
+					"and every plan warns about them when allowed. Defaults to false.",
+				Optional: true,
+			},
+			"batch_size": schema.Int64Attribute{
+				MarkdownDescription: "Apply the permissions at most this many at a time, for roles with thousands of permissions whose update " +
+					"times out behind a proxy. Superset can only replace the permissions of a role, so each request sends every permission " +
+					"applied so far and the last one sends them all: batching bounds the grants Superset inserts per request, not the size of " +
+					"the requests, and sends more requests in total. The permissions are read back once all batches are applied. " +
+					"Applied in a single request when not set.",
+				Optional: true,
+			},
+			"keep_unknown_permissions": schema.BoolAttribute{
+				MarkdownDescription: "Keep the permissions granted to the role outside Terraform instead of revoking them. They are then only reported in " +
+					"unknown_permissions, and left out of resource_permissions so they do not show as drift. Permissions removed from " +
+					"resource_permissions or database_access are still revoked. Defaults to false, which makes the resource authoritative: " +
+					"refreshes report the permissions granted outside Terraform as drift of resource_permissions, and the next apply revokes them.",
+				Optional: true,
+			},
+			"unknown_permissions": schema.SetNestedAttribute{
+				MarkdownDescription: "Permissions granted to the role in Superset that are not declared in resource_permissions or database_access, " +
+					"including those matched by ignore_permissions. Lets hand-granted permissions be audited, with keep_unknown_permissions set, " +
+					"before they are revoked.",
+				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.Int64Attribute{
-							Description: "The unique identifier of the permission.",
-							Computed:    true,
+							MarkdownDescription: "The unique identifier of the permission.",
+							Computed:            true,
 						},
 						"permission": schema.StringAttribute{
-							Description: "The name of the permission.",
-							Required:    true,
+							MarkdownDescription: "The name of the permission.",
+							Computed:            true,
 						},
 						"view_menu": schema.StringAttribute{
-							Description: "The name of the view menu associated with the permission.",
-							Required:    true,
+							MarkdownDescription: "The name of the view menu associated with the permission.",
+							Computed:            true,
 						},
 					},
 				},
 			},
+			"ignore_permissions": schema.SetNestedAttribute{
+				MarkdownDescription: "Permissions granted to the role outside Terraform that are neither reported as drift nor revoked, " +
+					"e.g. the menu_access companions Superset adds when granting can_read on some views. " +
+					"Each field is an exact name, or a regular expression when wrapped in slashes (e.g. \"/^menu_access$/\").",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"permission": schema.StringAttribute{
+							MarkdownDescription: "Name of the permission, or a regular expression wrapped in slashes.",
+							Required:            true,
+							Validators: []validator.String{
+								nameMatcherValidator{},
+							},
+						},
+						"view_menu": schema.StringAttribute{
+							MarkdownDescription: "Name of the view menu, or a regular expression wrapped in slashes.",
+							Required:            true,
+							Validators: []validator.String{
+								nameMatcherValidator{},
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
 
+// ValidateConfig checks batch_size.
+func (r *rolePermissionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var batchSize types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("batch_size"), &batchSize)...)
+	if resp.Diagnostics.HasError() || batchSize.IsNull() || batchSize.IsUnknown() {
+		return
+	}
+
+	if batchSize.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("batch_size"),
+			"Invalid Batch Size",
+			fmt.Sprintf("batch_size must be at least 1, got %d.", batchSize.ValueInt64()),
+		)
+	}
+}
+
+// ModifyPlan refuses to manage the permissions of a built-in role unless allow_builtin_role is set,
+// and warns about it when it is, including when the permissions are about to be cleared.
+func (r *rolePermissionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var roleName types.String
+	var allowBuiltinRole types.Bool
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("role_name"), &roleName)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("allow_builtin_role"), &allowBuiltinRole)...)
+	} else {
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("role_name"), &roleName)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_builtin_role"), &allowBuiltinRole)...)
+	}
+	if resp.Diagnostics.HasError() || roleName.IsUnknown() || allowBuiltinRole.IsUnknown() {
+		return
+	}
+	if !slices.Contains(client.BuiltInRoleNames, roleName.ValueString()) {
+		return
+	}
+
+	if !allowBuiltinRole.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role_name"),
+			"Built-In Role",
+			fmt.Sprintf("%q is a built-in role of Superset, whose permissions are not managed unless allow_builtin_role = true is set, "+
+				"as a mistake can lock users out of Superset.", roleName.ValueString()),
+		)
+		return
+	}
+
+	action := "managed"
+	if req.Plan.Raw.IsNull() {
+		action = "cleared"
+	}
+	tflog.Warn(ctx, "Managing the permissions of a built-in role", map[string]interface{}{
+		"roleName": roleName.ValueString(),
+		"action":   action,
+	})
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("role_name"),
+		"Managing a Built-In Role",
+		fmt.Sprintf("The permissions of %q, a built-in role of Superset, are %s by Terraform because allow_builtin_role is set. "+
+			"Review the plan carefully: a mistake can lock users out of Superset.", roleName.ValueString(), action),
+	)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *rolePermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	tflog.Debug(ctx, "Starting Create method")
+	ctx = r.client.NewLogSubsystem(ctx, logSubsystemRolePermissions)
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_role_permissions", &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan rolePermissionsResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		tflog.Debug(ctx, "Exiting Create due to error in retrieving plan", map[string]interface{}{
+		tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Exiting Create due to error in retrieving plan", map[string]interface{}{
 			"diagnostics": resp.Diagnostics,
 		})
 		return
 	}
 
-	tflog.Debug(ctx, "Plan obtained", map[string]interface{}{
+	ctx, cancel, err := withOperationTimeout(ctx, plan.Timeouts, "create")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Timeout", err.Error())
+		return
+	}
+	defer cancel()
+	supersetClient := r.client.WithContext(ctx)
+	// Replacing the permissions of a built-in role is refused by the client unless explicitly allowed.
+	supersetClient.AllowBuiltInRoleChanges = supersetClient.AllowBuiltInRoleChanges || plan.AllowBuiltinRole.ValueBool()
+
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Plan obtained", map[string]interface{}{
 		"roleName": plan.RoleName.ValueString(),
 	})
 
 	// Get the role ID based on role name
-	roleID, err := r.client.GetRoleIDByName(plan.RoleName.ValueString())
+	roleID, err := supersetClient.GetRoleIDByName(plan.RoleName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error finding role",
@@ -125,31 +325,78 @@ func (r *rolePermissionsResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	tflog.Debug(ctx, "Role ID obtained", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Role ID obtained", map[string]interface{}{
 		"roleID": roleID,
 	})
 
+	// Resolve all planned permissions with a single lookup
+	resourcePermissions, missing, err := resolveResourcePermissions(supersetClient, plan.ResourcePermissions, plan.IgnoreMissing.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error finding permission IDs",
+			err.Error(),
+		)
+		return
+	}
+	if len(missing) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Skipped Missing Permissions",
+			fmt.Sprintf("The following permissions do not exist in this Superset instance and were not granted to role '%s': %s",
+				plan.RoleName.ValueString(), strings.Join(missing, ", ")),
+		)
+	}
+
 	// Prepare permission IDs from plan using a map to ensure unique IDs
-	var resourcePermissions []resourcePermissionModel
 	permissionIDs := map[int64]bool{}
-	for _, perm := range plan.ResourcePermissions {
-		permID, err := r.client.GetPermissionIDByNameAndView(perm.Permission.ValueString(), perm.ViewMenu.ValueString())
+	for _, perm := range resourcePermissions {
+		if !perm.ID.IsNull() {
+			permissionIDs[perm.ID.ValueInt64()] = true
+		}
+	}
+
+	databaseAccess, err := resolveDatabaseAccess(supersetClient, plan.DatabaseAccess)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error resolving database access",
+			fmt.Sprintf("Could not resolve database_access permissions: %s", err),
+		)
+		return
+	}
+	for _, access := range databaseAccess {
+		permissionIDs[access.ID.ValueInt64()] = true
+	}
+
+	// Permissions matching ignore_permissions are kept as granted in Superset.
+	ignoreRules, err := compilePermissionRules(plan.IgnorePermissions)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Ignore Permissions", err.Error())
+		return
+	}
+	ignored, err := ignoredPermissions(supersetClient, roleID, ignoreRules)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading role permissions",
+			fmt.Sprintf("Could not read permissions for role ID %d: %s", roleID, err),
+		)
+		return
+	}
+	// With keep_unknown_permissions, the permissions granted outside Terraform are kept as well.
+	if plan.KeepUnknownPermissions.ValueBool() {
+		kept, err := keptUnknownPermissions(supersetClient, roleID, nil)
 		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error finding permission ID",
-				fmt.Sprintf("Could not find permission ID for '%s' and view '%s': %s", perm.Permission.ValueString(), perm.ViewMenu.ValueString(), err),
+				"Error reading role permissions",
+				fmt.Sprintf("Could not read permissions for role ID %d: %s", roleID, err),
 			)
 			return
 		}
-		permissionIDs[permID] = true
-		resourcePermissions = append(resourcePermissions, resourcePermissionModel{
-			ID:         types.Int64Value(permID),
-			Permission: perm.Permission,
-			ViewMenu:   perm.ViewMenu,
-		})
+		ignored = append(ignored, kept...)
+	}
+	for _, permission := range ignored {
+		permissionIDs[permission.ID] = true
 	}
 
-	tflog.Debug(ctx, "Permission IDs prepared", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Permission IDs prepared", map[string]interface{}{
 		"permissionIDs": permissionIDs,
 	})
 
@@ -159,12 +406,12 @@ func (r *rolePermissionsResource) Create(ctx context.Context, req resource.Creat
 		permIDList = append(permIDList, id)
 	}
 
-	tflog.Debug(ctx, "Permission ID list for API call", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Permission ID list for API call", map[string]interface{}{
 		"permIDList": permIDList,
 	})
 
 	// Update role permissions using the client
-	if err := r.client.UpdateRolePermissions(roleID, permIDList); err != nil {
+	if err := updateRolePermissions(supersetClient, roleID, permIDList, plan.BatchSize); err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating role permissions",
 			"Failed to update role permissions: "+err.Error(),
@@ -172,7 +419,7 @@ func (r *rolePermissionsResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	tflog.Debug(ctx, "Role permissions updated")
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Role permissions updated")
 
 	// Set the state with the updated data
 	// sort.Slice(resourcePermissions, func(i, j int) bool {
@@ -180,27 +427,36 @@ func (r *rolePermissionsResource) Create(ctx context.Context, req resource.Creat
 	// })
 
 	result := rolePermissionsResourceModel{
-		ID:                  types.StringValue(fmt.Sprintf("%d", roleID)),
-		RoleName:            plan.RoleName,
-		ResourcePermissions: resourcePermissions,
-		LastUpdated:         types.StringValue(time.Now().Format(time.RFC3339)),
+		ID:                     types.StringValue(fmt.Sprintf("%d", roleID)),
+		RoleName:               plan.RoleName,
+		ResourcePermissions:    resourcePermissions,
+		DatabaseAccess:         databaseAccess,
+		IgnoreMissing:          plan.IgnoreMissing,
+		IgnorePermissions:      plan.IgnorePermissions,
+		AllowBuiltinRole:       plan.AllowBuiltinRole,
+		BatchSize:              plan.BatchSize,
+		KeepUnknownPermissions: plan.KeepUnknownPermissions,
+		UnknownPermissions:     unknownPermissions(ignored, resourcePermissions, databaseAccess),
+		LastUpdated:            types.StringValue(time.Now().Format(time.RFC3339)),
+		Timeouts:               plan.Timeouts,
 	}
 
 	diags = resp.State.Set(ctx, &result)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		tflog.Debug(ctx, "Exiting Create due to error in setting state", map[string]interface{}{
+		tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Exiting Create due to error in setting state", map[string]interface{}{
 			"diagnostics": resp.Diagnostics,
 		})
 		return
 	}
 
-	tflog.Debug(ctx, "Create method completed successfully")
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Create method completed successfully")
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	tflog.Debug(ctx, "Starting Read method")
+	ctx = r.client.NewLogSubsystem(ctx, logSubsystemRolePermissions)
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Starting Read method")
 
 	// Get current state
 	var state rolePermissionsResourceModel
@@ -210,12 +466,19 @@ func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	tflog.Debug(ctx, "State obtained", map[string]interface{}{
+	ctx, cancel, err := withOperationTimeout(ctx, state.Timeouts, "read")
+	if err != nil {
+		addStoredTimeoutWarning(&resp.Diagnostics, err)
+	}
+	defer cancel()
+	supersetClient := r.client.WithContext(ctx)
+
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "State obtained", map[string]interface{}{
 		"roleName": state.RoleName.ValueString(),
 	})
 
 	// Get role ID
-	roleID, err := r.client.GetRoleIDByName(state.RoleName.ValueString())
+	roleID, err := supersetClient.GetRoleIDByName(state.RoleName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error finding role",
@@ -224,12 +487,12 @@ func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	tflog.Debug(ctx, "Role ID obtained", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Role ID obtained", map[string]interface{}{
 		"roleID": roleID,
 	})
 
 	// Get permissions from Superset
-	permissions, err := r.client.GetRolePermissions(roleID)
+	permissions, err := supersetClient.GetRolePermissions(roleID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading role permissions",
@@ -238,19 +501,79 @@ func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	tflog.Debug(ctx, "Permissions fetched from Superset", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Permissions fetched from Superset", map[string]interface{}{
 		"permissions": permissions,
 	})
 
+	ignoreRules, err := compilePermissionRules(state.IgnorePermissions)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Ignore Permissions", err.Error())
+		return
+	}
+	declared := map[client.PermissionPair]bool{}
+	for _, perm := range state.ResourcePermissions {
+		declared[client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()}] = true
+	}
+
+	// Permissions reported as unknown by the previous read were never declared, even if a refresh has
+	// since added them to resource_permissions. Right after an import nothing is declared yet, so the
+	// granted permissions are adopted rather than all reported as unknown.
+	unknown := map[client.PermissionPair]bool{}
+	for _, perm := range state.UnknownPermissions {
+		unknown[client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()}] = true
+	}
+	var declaredPermissions []resourcePermissionModel
+	for _, perm := range state.ResourcePermissions {
+		if !unknown[client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()}] {
+			declaredPermissions = append(declaredPermissions, perm)
+		}
+	}
+	if state.ResourcePermissions != nil {
+		state.UnknownPermissions = unknownPermissions(permissions, declaredPermissions, state.DatabaseAccess)
+	}
+
+	// With keep_unknown_permissions, the permissions granted outside Terraform are only reported in
+	// unknown_permissions, so they do not show as drift of resource_permissions.
+	kept := map[client.PermissionPair]bool{}
+	if state.KeepUnknownPermissions.ValueBool() {
+		for _, perm := range state.UnknownPermissions {
+			kept[client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()}] = true
+		}
+	}
+
+	// Database access grants declared through database_access are tracked there, keyed by database ID.
+	databaseAccessIndex := map[int64]int{}
+	for i, access := range state.DatabaseAccess {
+		databaseAccessIndex[access.DatabaseID.ValueInt64()] = i
+	}
+
 	// Map permissions to resource model
 	var resourcePermissions []resourcePermissionModel
 	for _, perm := range permissions {
-		tflog.Debug(ctx, "Processing fetched permission", map[string]interface{}{
+		tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Processing fetched permission", map[string]interface{}{
 			"ID":         perm.ID,
 			"Permission": perm.PermissionName,
 			"ViewMenu":   perm.ViewMenuName,
 		})
 
+		if perm.PermissionName == "database_access" {
+			if databaseID, ok := databaseIDFromViewMenu(perm.ViewMenuName); ok {
+				if i, ok := databaseAccessIndex[databaseID]; ok {
+					state.DatabaseAccess[i].ID = types.Int64Value(perm.ID)
+					state.DatabaseAccess[i].ViewMenu = types.StringValue(perm.ViewMenuName)
+					continue
+				}
+			}
+		}
+
+		// Permissions matching ignore_permissions are left out, unless they are also declared.
+		if ignoresPermission(ignoreRules, perm) && !declared[client.PermissionPair{Permission: perm.PermissionName, ViewMenu: perm.ViewMenuName}] {
+			continue
+		}
+		if kept[client.PermissionPair{Permission: perm.PermissionName, ViewMenu: perm.ViewMenuName}] {
+			continue
+		}
+
 		// Create mapped permission
 		mappedPermission := resourcePermissionModel{
 			ID:         types.Int64Value(perm.ID),
@@ -259,7 +582,7 @@ func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadReq
 		}
 
 		// Verify mapping immediately after setting the values
-		tflog.Debug(ctx, "Mapped Permission", map[string]interface{}{
+		tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Mapped Permission", map[string]interface{}{
 			"ID":         mappedPermission.ID.ValueInt64(),
 			"Permission": mappedPermission.Permission.ValueString(),
 			"ViewMenu":   mappedPermission.ViewMenu.ValueString(),
@@ -278,7 +601,7 @@ func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadReq
 		})
 	}
 
-	tflog.Debug(ctx, "Full content of resourcePermissions", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Full content of resourcePermissions", map[string]interface{}{
 		"resourcePermissions": debugResourcePermissions,
 	})
 
@@ -288,17 +611,31 @@ func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadReq
 	// })
 
 	for _, rp := range resourcePermissions {
-		tflog.Debug(ctx, "Mapped Permission in List", map[string]interface{}{
+		tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Mapped Permission in List", map[string]interface{}{
 			"ID":         rp.ID.ValueInt64(),
 			"Permission": rp.Permission.ValueString(),
 			"ViewMenu":   rp.ViewMenu.ValueString(),
 		})
 	}
 
-	tflog.Debug(ctx, "Final Permissions mapped to resource model", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Final Permissions mapped to resource model", map[string]interface{}{
 		"resourcePermissions": debugResourcePermissions,
 	})
 
+	// Permissions skipped because of ignore_missing are kept while they still don't exist in Superset,
+	// and dropped once they do so that the next plan grants them.
+	if state.IgnoreMissing.ValueBool() {
+		skipped, err := stillMissingPermissions(supersetClient, state.ResourcePermissions)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error finding permission IDs",
+				err.Error(),
+			)
+			return
+		}
+		resourcePermissions = append(resourcePermissions, skipped...)
+	}
+
 	// Overwrite state with refreshed values
 	state.ResourcePermissions = resourcePermissions
 	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
@@ -309,29 +646,49 @@ func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	tflog.Debug(ctx, "Read method completed successfully")
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Read method completed successfully")
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *rolePermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	tflog.Debug(ctx, "Starting Update method")
+	ctx = r.client.NewLogSubsystem(ctx, logSubsystemRolePermissions)
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Starting Update method")
+	if refuseInReadOnlyMode(r.client, "update", "superset_role_permissions", &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan rolePermissionsResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		tflog.Debug(ctx, "Exiting Update due to error in retrieving plan", map[string]interface{}{
+		tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Exiting Update due to error in retrieving plan", map[string]interface{}{
 			"diagnostics": resp.Diagnostics,
 		})
 		return
 	}
+	var state rolePermissionsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	tflog.Debug(ctx, "Plan obtained", map[string]interface{}{
+	ctx, cancel, err := withOperationTimeout(ctx, plan.Timeouts, "update")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Timeout", err.Error())
+		return
+	}
+	defer cancel()
+	supersetClient := r.client.WithContext(ctx)
+	// Replacing the permissions of a built-in role is refused by the client unless explicitly allowed.
+	supersetClient.AllowBuiltInRoleChanges = supersetClient.AllowBuiltInRoleChanges || plan.AllowBuiltinRole.ValueBool()
+
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Plan obtained", map[string]interface{}{
 		"roleName": plan.RoleName.ValueString(),
 	})
 
 	// Get the role ID based on role name
-	roleID, err := r.client.GetRoleIDByName(plan.RoleName.ValueString())
+	roleID, err := supersetClient.GetRoleIDByName(plan.RoleName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error finding role",
@@ -340,31 +697,79 @@ func (r *rolePermissionsResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	tflog.Debug(ctx, "Role ID obtained", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Role ID obtained", map[string]interface{}{
 		"roleID": roleID,
 	})
 
+	// Resolve all planned permissions with a single lookup
+	resourcePermissions, missing, err := resolveResourcePermissions(supersetClient, plan.ResourcePermissions, plan.IgnoreMissing.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error finding permission IDs",
+			err.Error(),
+		)
+		return
+	}
+	if len(missing) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Skipped Missing Permissions",
+			fmt.Sprintf("The following permissions do not exist in this Superset instance and were not granted to role '%s': %s",
+				plan.RoleName.ValueString(), strings.Join(missing, ", ")),
+		)
+	}
+
 	// Prepare permission IDs from plan using a map to ensure unique IDs
-	var resourcePermissions []resourcePermissionModel
 	permissionIDs := map[int64]bool{}
-	for _, perm := range plan.ResourcePermissions {
-		permID, err := r.client.GetPermissionIDByNameAndView(perm.Permission.ValueString(), perm.ViewMenu.ValueString())
+	for _, perm := range resourcePermissions {
+		if !perm.ID.IsNull() {
+			permissionIDs[perm.ID.ValueInt64()] = true
+		}
+	}
+
+	databaseAccess, err := resolveDatabaseAccess(supersetClient, plan.DatabaseAccess)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error resolving database access",
+			fmt.Sprintf("Could not resolve database_access permissions: %s", err),
+		)
+		return
+	}
+	for _, access := range databaseAccess {
+		permissionIDs[access.ID.ValueInt64()] = true
+	}
+
+	// Permissions matching ignore_permissions are kept as granted in Superset.
+	ignoreRules, err := compilePermissionRules(plan.IgnorePermissions)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Ignore Permissions", err.Error())
+		return
+	}
+	ignored, err := ignoredPermissions(supersetClient, roleID, ignoreRules)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading role permissions",
+			fmt.Sprintf("Could not read permissions for role ID %d: %s", roleID, err),
+		)
+		return
+	}
+	// With keep_unknown_permissions, the permissions granted outside Terraform are kept as well, but those the
+	// previous state managed are revoked once removed from the plan.
+	if plan.KeepUnknownPermissions.ValueBool() {
+		kept, err := keptUnknownPermissions(supersetClient, roleID, &state)
 		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error finding permission ID",
-				fmt.Sprintf("Could not find permission ID for '%s' and view '%s': %s", perm.Permission.ValueString(), perm.ViewMenu.ValueString(), err),
+				"Error reading role permissions",
+				fmt.Sprintf("Could not read permissions for role ID %d: %s", roleID, err),
 			)
 			return
 		}
-		permissionIDs[permID] = true
-		resourcePermissions = append(resourcePermissions, resourcePermissionModel{
-			ID:         types.Int64Value(permID),
-			Permission: perm.Permission,
-			ViewMenu:   perm.ViewMenu,
-		})
+		ignored = append(ignored, kept...)
+	}
+	for _, permission := range ignored {
+		permissionIDs[permission.ID] = true
 	}
 
-	tflog.Debug(ctx, "Permission IDs prepared", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Permission IDs prepared", map[string]interface{}{
 		"permissionIDs": permissionIDs,
 	})
 
@@ -374,12 +779,12 @@ func (r *rolePermissionsResource) Update(ctx context.Context, req resource.Updat
 		permIDList = append(permIDList, id)
 	}
 
-	tflog.Debug(ctx, "Permission ID list for API call", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Permission ID list for API call", map[string]interface{}{
 		"permIDList": permIDList,
 	})
 
 	// Update role permissions using the client
-	if err := r.client.UpdateRolePermissions(roleID, permIDList); err != nil {
+	if err := updateRolePermissions(supersetClient, roleID, permIDList, plan.BatchSize); err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating role permissions",
 			"Failed to update role permissions: "+err.Error(),
@@ -387,7 +792,7 @@ func (r *rolePermissionsResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	tflog.Debug(ctx, "Role permissions updated")
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Role permissions updated")
 
 	// Set the state with the updated data
 	// sort.Slice(resourcePermissions, func(i, j int) bool {
@@ -395,42 +800,64 @@ func (r *rolePermissionsResource) Update(ctx context.Context, req resource.Updat
 	// })
 
 	result := rolePermissionsResourceModel{
-		ID:                  types.StringValue(fmt.Sprintf("%d", roleID)),
-		RoleName:            plan.RoleName,
-		ResourcePermissions: resourcePermissions,
-		LastUpdated:         types.StringValue(time.Now().Format(time.RFC3339)),
+		ID:                     types.StringValue(fmt.Sprintf("%d", roleID)),
+		RoleName:               plan.RoleName,
+		ResourcePermissions:    resourcePermissions,
+		DatabaseAccess:         databaseAccess,
+		IgnoreMissing:          plan.IgnoreMissing,
+		IgnorePermissions:      plan.IgnorePermissions,
+		AllowBuiltinRole:       plan.AllowBuiltinRole,
+		BatchSize:              plan.BatchSize,
+		KeepUnknownPermissions: plan.KeepUnknownPermissions,
+		UnknownPermissions:     unknownPermissions(ignored, resourcePermissions, databaseAccess),
+		LastUpdated:            types.StringValue(time.Now().Format(time.RFC3339)),
+		Timeouts:               plan.Timeouts,
 	}
 
 	diags = resp.State.Set(ctx, &result)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		tflog.Debug(ctx, "Exiting Update due to error in setting state", map[string]interface{}{
+		tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Exiting Update due to error in setting state", map[string]interface{}{
 			"diagnostics": resp.Diagnostics,
 		})
 		return
 	}
 
-	tflog.Debug(ctx, "Update method completed successfully")
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Update method completed successfully")
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *rolePermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	tflog.Debug(ctx, "Starting Delete method")
+	ctx = r.client.NewLogSubsystem(ctx, logSubsystemRolePermissions)
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Starting Delete method")
+	if refuseInReadOnlyMode(r.client, "delete", "superset_role_permissions", &resp.Diagnostics) {
+		return
+	}
+
 	var state rolePermissionsResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		tflog.Debug(ctx, "Exiting Delete due to error in getting state", map[string]interface{}{
+		tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Exiting Delete due to error in getting state", map[string]interface{}{
 			"diagnostics": resp.Diagnostics,
 		})
 		return
 	}
 
-	tflog.Debug(ctx, "State obtained", map[string]interface{}{
+	ctx, cancel, err := withOperationTimeout(ctx, state.Timeouts, "delete")
+	if err != nil {
+		addStoredTimeoutWarning(&resp.Diagnostics, err)
+	}
+	defer cancel()
+	supersetClient := r.client.WithContext(ctx)
+	// Clearing the permissions of a built-in role is refused by the client unless explicitly allowed.
+	supersetClient.AllowBuiltInRoleChanges = supersetClient.AllowBuiltInRoleChanges || state.AllowBuiltinRole.ValueBool()
+
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "State obtained", map[string]interface{}{
 		"roleName": state.RoleName.ValueString(),
 	})
 
-	roleID, err := r.client.GetRoleIDByName(state.RoleName.ValueString())
+	roleID, err := supersetClient.GetRoleIDByName(state.RoleName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error finding role",
@@ -439,12 +866,51 @@ func (r *rolePermissionsResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	tflog.Debug(ctx, "Role ID obtained", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Role ID obtained", map[string]interface{}{
 		"roleID": roleID,
 	})
 
-	err = r.client.ClearRolePermissions(roleID)
+	// Permissions matching ignore_permissions are managed outside Terraform and stay granted.
+	ignoreRules, err := compilePermissionRules(state.IgnorePermissions)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Ignore Permissions", err.Error())
+		return
+	}
+	ignored, err := ignoredPermissions(supersetClient, roleID, ignoreRules)
 	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading role permissions",
+			fmt.Sprintf("Could not read permissions for role ID %d: %s", roleID, err),
+		)
+		return
+	}
+	// With keep_unknown_permissions, the permissions granted outside Terraform stay granted as well.
+	if state.KeepUnknownPermissions.ValueBool() {
+		kept, err := keptUnknownPermissions(supersetClient, roleID, &state)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading role permissions",
+				fmt.Sprintf("Could not read permissions for role ID %d: %s", roleID, err),
+			)
+			return
+		}
+		ignored = append(ignored, kept...)
+	}
+	var ignoredIDs []int64
+	seen := map[int64]bool{}
+	for _, permission := range ignored {
+		if !seen[permission.ID] {
+			seen[permission.ID] = true
+			ignoredIDs = append(ignoredIDs, permission.ID)
+		}
+	}
+
+	if len(ignoredIDs) > 0 {
+		err = supersetClient.UpdateRolePermissions(roleID, ignoredIDs)
+	} else {
+		err = supersetClient.ClearRolePermissions(roleID)
+	}
+	if err = withBuiltInRoleHint(err); err != nil {
 		resp.Diagnostics.AddError(
 			"Error clearing role permissions",
 			fmt.Sprintf("Could not clear permissions for role ID %d: %s", roleID, err),
@@ -452,10 +918,217 @@ func (r *rolePermissionsResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	tflog.Debug(ctx, "Role permissions cleared")
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Role permissions cleared")
 
 	resp.State.RemoveResource(ctx)
-	tflog.Debug(ctx, "Delete method completed successfully")
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Delete method completed successfully")
+}
+
+// updateRolePermissions replaces the permissions of the role, in batches when batch_size is set.
+func updateRolePermissions(supersetClient *client.Client, roleID int64, permissionIDs []int64, batchSize types.Int64) error {
+	if batchSize.IsNull() {
+		return withBuiltInRoleHint(supersetClient.UpdateRolePermissions(roleID, permissionIDs))
+	}
+	return withBuiltInRoleHint(supersetClient.UpdateRolePermissionsInBatches(roleID, permissionIDs, int(batchSize.ValueInt64())))
+}
+
+// withBuiltInRoleHint adds allow_builtin_role to the error of a refused built-in role, which only names the
+// provider setting.
+func withBuiltInRoleHint(err error) error {
+	if errors.Is(err, client.ErrBuiltInRole) {
+		return fmt.Errorf("%w; allow_builtin_role = true on this resource allows it as well", err)
+	}
+	return err
+}
+
+// resolveResourcePermissions looks up the IDs of the planned permissions with a single fetch.
+// A pair missing from Superset is an error, unless ignoreMissing is set, in which case it is
+// kept with a null ID and described in the returned list so the caller can warn about it.
+func resolveResourcePermissions(supersetClient *client.Client, planned []resourcePermissionModel, ignoreMissing bool) ([]resourcePermissionModel, []string, error) {
+	var pairs []client.PermissionPair
+	for _, perm := range planned {
+		pairs = append(pairs, client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()})
+	}
+	resolvedIDs, err := supersetClient.GetPermissionIDsByNameAndView(pairs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not fetch permissions from Superset: %w", err)
+	}
+
+	var resourcePermissions []resourcePermissionModel
+	var missing []string
+	for _, perm := range planned {
+		permID, ok := resolvedIDs[client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()}]
+		if !ok {
+			if !ignoreMissing {
+				return nil, nil, fmt.Errorf("could not find permission ID for '%s' and view '%s': the pair does not exist in Superset", perm.Permission.ValueString(), perm.ViewMenu.ValueString())
+			}
+			missing = append(missing, fmt.Sprintf("%s on %s", perm.Permission.ValueString(), perm.ViewMenu.ValueString()))
+			resourcePermissions = append(resourcePermissions, resourcePermissionModel{
+				ID:         types.Int64Null(),
+				Permission: perm.Permission,
+				ViewMenu:   perm.ViewMenu,
+			})
+			continue
+		}
+		resourcePermissions = append(resourcePermissions, resourcePermissionModel{
+			ID:         types.Int64Value(permID),
+			Permission: perm.Permission,
+			ViewMenu:   perm.ViewMenu,
+		})
+	}
+	return resourcePermissions, missing, nil
+}
+
+// stillMissingPermissions returns the permissions of the prior state that were skipped by
+// ignore_missing (recorded with a null ID) and still do not exist in Superset.
+func stillMissingPermissions(supersetClient *client.Client, prior []resourcePermissionModel) ([]resourcePermissionModel, error) {
+	var skipped []resourcePermissionModel
+	var pairs []client.PermissionPair
+	for _, perm := range prior {
+		if perm.ID.IsNull() {
+			skipped = append(skipped, perm)
+			pairs = append(pairs, client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()})
+		}
+	}
+	if len(skipped) == 0 {
+		return nil, nil
+	}
+
+	resolvedIDs, err := supersetClient.GetPermissionIDsByNameAndView(pairs)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch permissions from Superset: %w", err)
+	}
+
+	var stillMissing []resourcePermissionModel
+	for _, perm := range skipped {
+		if _, ok := resolvedIDs[client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()}]; !ok {
+			stillMissing = append(stillMissing, perm)
+		}
+	}
+	return stillMissing, nil
+}
+
+// resolveDatabaseAccess looks up the database_access permission of every planned database ID.
+// The view menu Superset creates for a database is "[database_name].(id:N)", so the name is
+// fetched from the database connection at apply time rather than assembled in configuration.
+func resolveDatabaseAccess(supersetClient *client.Client, planned []databaseAccessModel) ([]databaseAccessModel, error) {
+	var databaseAccess []databaseAccessModel
+	for _, access := range planned {
+		databaseID := access.DatabaseID.ValueInt64()
+		db, err := supersetClient.GetDatabaseConnectionByID(databaseID)
+		if err != nil {
+			return nil, fmt.Errorf("could not read database ID %d: %w", databaseID, err)
+		}
+
+		result, _ := db["result"].(map[string]interface{})
+		databaseName, ok := result["database_name"].(string)
+		if !ok {
+			return nil, fmt.Errorf("the response for database ID %d does not contain a valid 'database_name' field", databaseID)
+		}
+
+		viewMenu := databaseViewMenu(databaseName, databaseID)
+		permID, err := supersetClient.GetPermissionIDByNameAndView("database_access", viewMenu)
+		if err != nil {
+			return nil, err
+		}
+
+		databaseAccess = append(databaseAccess, databaseAccessModel{
+			DatabaseID: access.DatabaseID,
+			ID:         types.Int64Value(permID),
+			ViewMenu:   types.StringValue(viewMenu),
+		})
+	}
+	return databaseAccess, nil
+}
+
+// unknownPermissions returns the granted permissions that are declared neither in resource_permissions
+// nor through database_access, i.e. the ones granted outside Terraform.
+func unknownPermissions(granted []client.Permission, declared []resourcePermissionModel, databaseAccess []databaseAccessModel) []resourcePermissionModel {
+	declaredPairs := map[client.PermissionPair]bool{}
+	for _, perm := range declared {
+		declaredPairs[client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()}] = true
+	}
+	declaredDatabases := map[int64]bool{}
+	for _, access := range databaseAccess {
+		declaredDatabases[access.DatabaseID.ValueInt64()] = true
+	}
+
+	var unknown []resourcePermissionModel
+	seen := map[int64]bool{}
+	for _, perm := range granted {
+		if seen[perm.ID] || declaredPairs[client.PermissionPair{Permission: perm.PermissionName, ViewMenu: perm.ViewMenuName}] {
+			continue
+		}
+		seen[perm.ID] = true
+		if perm.PermissionName == "database_access" {
+			if databaseID, ok := databaseIDFromViewMenu(perm.ViewMenuName); ok && declaredDatabases[databaseID] {
+				continue
+			}
+		}
+		unknown = append(unknown, resourcePermissionModel{
+			ID:         types.Int64Value(perm.ID),
+			Permission: types.StringValue(perm.PermissionName),
+			ViewMenu:   types.StringValue(perm.ViewMenuName),
+		})
+	}
+	return unknown
+}
+
+// keptUnknownPermissions returns the permissions granted to the role that keep_unknown_permissions keeps:
+// all of them on create, and on update those the previous state did not manage, so a permission removed
+// from resource_permissions or database_access is still revoked.
+func keptUnknownPermissions(supersetClient *client.Client, roleID int64, previous *rolePermissionsResourceModel) ([]client.Permission, error) {
+	granted, err := supersetClient.GetRolePermissions(roleID)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return granted, nil
+	}
+
+	unknown := map[client.PermissionPair]bool{}
+	for _, perm := range previous.UnknownPermissions {
+		unknown[client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()}] = true
+	}
+	var managed []resourcePermissionModel
+	for _, perm := range previous.ResourcePermissions {
+		if !unknown[client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()}] {
+			managed = append(managed, perm)
+		}
+	}
+
+	ids := map[int64]bool{}
+	for _, perm := range unknownPermissions(granted, managed, previous.DatabaseAccess) {
+		ids[perm.ID.ValueInt64()] = true
+	}
+	var kept []client.Permission
+	for _, perm := range granted {
+		if ids[perm.ID] {
+			kept = append(kept, perm)
+		}
+	}
+	return kept, nil
+}
+
+// databaseViewMenu returns the view menu name Superset uses for the database_access permission of a database.
+func databaseViewMenu(databaseName string, databaseID int64) string {
+	return fmt.Sprintf("[%s].(id:%d)", databaseName, databaseID)
+}
+
+// databaseViewMenuPattern matches the trailing "(id:N)" of a database view menu.
+var databaseViewMenuPattern = regexp.MustCompile(`^\[.*\]\.\(id:(\d+)\)$`)
+
+// databaseIDFromViewMenu extracts the database ID from a database view menu such as "[Trino].(id:34)".
+func databaseIDFromViewMenu(viewMenu string) (int64, bool) {
+	match := databaseViewMenuPattern.FindStringSubmatch(viewMenu)
+	if match == nil {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
 }
 
 // Configure adds the provider configured client to the resource.
@@ -478,7 +1151,8 @@ func (r *rolePermissionsResource) Configure(_ context.Context, req resource.Conf
 
 // ImportState imports the resource state.
 func (r *rolePermissionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	tflog.Debug(ctx, "Starting ImportState method", map[string]interface{}{
+	ctx = r.client.NewLogSubsystem(ctx, logSubsystemRolePermissions)
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "Starting ImportState method", map[string]interface{}{
 		"import_id": req.ID,
 	})
 
@@ -504,8 +1178,83 @@ func (r *rolePermissionsResource) ImportState(ctx context.Context, req resource.
 		return
 	}
 
-	tflog.Debug(ctx, "ImportState completed successfully", map[string]interface{}{
+	tflog.SubsystemDebug(ctx, logSubsystemRolePermissions, "ImportState completed successfully", map[string]interface{}{
 		"import_id": req.ID,
 		"role_name": role.Name,
 	})
 }
+
+// rolePermissionsResourceModelV0 maps the version 0 schema data, where resource_permissions was a list.
+type rolePermissionsResourceModelV0 struct {
+	ID                  types.String              `tfsdk:"id"`
+	RoleName            types.String              `tfsdk:"role_name"`
+	ResourcePermissions []resourcePermissionModel `tfsdk:"resource_permissions"`
+	LastUpdated         types.String              `tfsdk:"last_updated"`
+}
+
+// UpgradeState upgrades prior versions of the resource state to the current schema.
+func (r *rolePermissionsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"last_updated": schema.StringAttribute{
+						Computed: true,
+					},
+					"role_name": schema.StringAttribute{
+						Required: true,
+					},
+					"resource_permissions": schema.ListNestedAttribute{
+						Required: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id": schema.Int64Attribute{
+									Computed: true,
+								},
+								"permission": schema.StringAttribute{
+									Required: true,
+								},
+								"view_menu": schema.StringAttribute{
+									Required: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			StateUpgrader: upgradeRolePermissionsStateV0toV1,
+		},
+	}
+}
+
+// upgradeRolePermissionsStateV0toV1 moves resource_permissions from a list to a set.
+// Duplicate entries, which the list allowed, collapse into a single set element.
+func upgradeRolePermissionsStateV0toV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior rolePermissionsResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := map[resourcePermissionModel]bool{}
+	var resourcePermissions []resourcePermissionModel
+	for _, perm := range prior.ResourcePermissions {
+		if seen[perm] {
+			continue
+		}
+		seen[perm] = true
+		resourcePermissions = append(resourcePermissions, perm)
+	}
+
+	upgraded := rolePermissionsResourceModel{
+		ID:                  prior.ID,
+		RoleName:            prior.RoleName,
+		ResourcePermissions: resourcePermissions,
+		LastUpdated:         prior.LastUpdated,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+}
@@ -1,10 +1,18 @@
 package provider
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/jarcoal/httpmock"
+	"terraform-provider-superset/internal/client"
 )
 
 func TestAccRolePermissionsResource(t *testing.T) {
@@ -30,7 +38,7 @@ func TestAccRolePermissionsResource(t *testing.T) {
 			}`))
 
 		// Mock the Superset API response for fetching permissions resources
-		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page_size:5000)",
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page:0,page_size:5000)",
 			httpmock.NewStringResponder(200, `{ "result": [
 				{
 					"id": 240,
@@ -88,8 +96,10 @@ func TestAccRolePermissionsResource(t *testing.T) {
 					Check: resource.ComposeAggregateTestCheckFunc(
 						resource.TestCheckResourceAttr("superset_role_permissions.team", "role_name", "DWH-DB-Connect"),
 						resource.TestCheckResourceAttr("superset_role_permissions.team", "resource_permissions.#", "1"),
-						resource.TestCheckResourceAttr("superset_role_permissions.team", "resource_permissions.0.permission", "database_access"),
-						resource.TestCheckResourceAttr("superset_role_permissions.team", "resource_permissions.0.view_menu", "[SelfPostgreSQL].(id:1)"),
+						resource.TestCheckTypeSetElemNestedAttrs("superset_role_permissions.team", "resource_permissions.*", map[string]string{
+							"permission": "database_access",
+							"view_menu":  "[SelfPostgreSQL].(id:1)",
+						}),
 					),
 				},
 				// ImportState testing
@@ -120,7 +130,7 @@ func TestAccRolePermissionsResource(t *testing.T) {
 			}`))
 
 		// Mock the Superset API response for fetching permissions resources
-		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page_size:5000)",
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page:0,page_size:5000)",
 			httpmock.NewStringResponder(200, `{ "result": [
 				{
 					"id": 240,
@@ -203,11 +213,549 @@ func TestAccRolePermissionsResource(t *testing.T) {
 					Check: resource.ComposeAggregateTestCheckFunc(
 						resource.TestCheckResourceAttr("superset_role_permissions.team", "role_name", "DWH-DB-Connect"),
 						resource.TestCheckResourceAttr("superset_role_permissions.team", "resource_permissions.#", "2"),
-						resource.TestCheckResourceAttr("superset_role_permissions.team", "resource_permissions.1.permission", "schema_access"),
-						resource.TestCheckResourceAttr("superset_role_permissions.team", "resource_permissions.1.view_menu", "[Trino].[devoriginationzestorage]"),
+						resource.TestCheckTypeSetElemNestedAttrs("superset_role_permissions.team", "resource_permissions.*", map[string]string{
+							"permission": "schema_access",
+							"view_menu":  "[Trino].[devoriginationzestorage]",
+						}),
 					),
 				},
 			},
 		})
 	})
+
+	t.Run("DatabaseAccess", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		// Mock the Superset API login response
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+			httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+		// Mock the Superset API response for fetching roles
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+			httpmock.NewStringResponder(200, `{
+				"result": [
+					{"id": 129, "name": "DWH-DB-Connect"}
+				]
+			}`))
+
+		// Mock the Superset API response for reading the referenced database connection
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/208/connection",
+			httpmock.NewStringResponder(200, `{"result": {"id": 208, "database_name": "DWH_database_connection4"}}`))
+
+		// Mock the Superset API response for fetching permissions resources
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page:0,page_size:5000)",
+			httpmock.NewStringResponder(200, `{ "result": [
+				{
+					"id": 240,
+					"permission": {
+						"name": "database_access"
+					},
+					"view_menu": {
+						"name": "[SelfPostgreSQL].(id:1)"
+					}
+				},
+				{
+					"id": 512,
+					"permission": {
+						"name": "database_access"
+					},
+					"view_menu": {
+						"name": "[DWH_database_connection4].(id:208)"
+					}
+				}
+		]}`))
+
+		// Mock the Superset API response for updating role permissions
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/129/permissions",
+			httpmock.NewStringResponder(200, `{"status": "success"}`))
+
+		// Mock the Superset API response for fetching role permissions
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/129/permissions/",
+			httpmock.NewStringResponder(200, `{ "result": [
+				{
+					"id": 240,
+					"permission_name": "database_access",
+					"view_menu_name": "[SelfPostgreSQL].(id:1)"
+				},
+				{
+					"id": 512,
+					"permission_name": "database_access",
+					"view_menu_name": "[DWH_database_connection4].(id:208)"
+				}
+		]}`))
+
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig + `
+	resource "superset_role_permissions" "team" {
+	role_name            = "DWH-DB-Connect"
+	resource_permissions = [
+		{
+			permission = "database_access"
+			view_menu  = "[SelfPostgreSQL].(id:1)"
+		},
+	]
+	database_access = [
+		{
+			database_id = 208
+		},
+	]
+	}
+	`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("superset_role_permissions.team", "resource_permissions.#", "1"),
+						resource.TestCheckResourceAttr("superset_role_permissions.team", "database_access.#", "1"),
+						resource.TestCheckTypeSetElemNestedAttrs("superset_role_permissions.team", "database_access.*", map[string]string{
+							"database_id": "208",
+							"id":          "512",
+							"view_menu":   "[DWH_database_connection4].(id:208)",
+						}),
+					),
+				},
+			},
+		})
+	})
+	t.Run("IgnoreMissing", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		// Mock the Superset API login response
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+			httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+		// Mock the Superset API response for fetching roles
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+			httpmock.NewStringResponder(200, `{
+				"result": [
+					{"id": 129, "name": "DWH-DB-Connect"}
+				]
+			}`))
+
+		// Mock the Superset API response for fetching permissions resources, without can_export on Chart
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page:0,page_size:5000)",
+			httpmock.NewStringResponder(200, `{ "result": [
+				{
+					"id": 240,
+					"permission": {
+						"name": "database_access"
+					},
+					"view_menu": {
+						"name": "[SelfPostgreSQL].(id:1)"
+					}
+				}
+		]}`))
+
+		// Mock the Superset API response for updating role permissions
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/129/permissions",
+			httpmock.NewStringResponder(200, `{"status": "success"}`))
+
+		// Mock the Superset API response for fetching role permissions
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/129/permissions/",
+			httpmock.NewStringResponder(200, `{ "result": [
+				{
+					"id": 240,
+					"permission_name": "database_access",
+					"view_menu_name": "[SelfPostgreSQL].(id:1)"
+				}
+		]}`))
+
+		// Mock the Superset API response for deleting role permissions
+		httpmock.RegisterResponder("DELETE", "http://superset-host/api/v1/security/roles/129/permissions",
+			httpmock.NewStringResponder(204, ""))
+
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig + `
+	resource "superset_role_permissions" "team" {
+	role_name            = "DWH-DB-Connect"
+	ignore_missing       = true
+	resource_permissions = [
+		{
+			permission = "database_access"
+			view_menu  = "[SelfPostgreSQL].(id:1)"
+		},
+		{
+			permission = "can_export"
+			view_menu  = "Chart"
+		},
+	]
+	}
+	`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("superset_role_permissions.team", "resource_permissions.#", "2"),
+						resource.TestCheckTypeSetElemNestedAttrs("superset_role_permissions.team", "resource_permissions.*", map[string]string{
+							"permission": "database_access",
+							"id":         "240",
+						}),
+						resource.TestCheckTypeSetElemNestedAttrs("superset_role_permissions.team", "resource_permissions.*", map[string]string{
+							"permission": "can_export",
+							"view_menu":  "Chart",
+						}),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("IgnorePermissions", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		// Mock the Superset API login response
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+			httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+		// Mock the Superset API response for fetching roles
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+			httpmock.NewStringResponder(200, `{
+				"result": [
+					{"id": 129, "name": "DWH-DB-Connect"}
+				]
+			}`))
+
+		// Mock the Superset API response for fetching permissions resources
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page:0,page_size:5000)",
+			httpmock.NewStringResponder(200, `{ "result": [
+				{
+					"id": 240,
+					"permission": {
+						"name": "database_access"
+					},
+					"view_menu": {
+						"name": "[SelfPostgreSQL].(id:1)"
+					}
+				}
+		]}`))
+
+		// Mock the Superset API response for updating role permissions, recording the granted IDs
+		var granted []int64
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/129/permissions",
+			func(req *http.Request) (*http.Response, error) {
+				var payload struct {
+					PermissionViewMenuIDs []int64 `json:"permission_view_menu_ids"`
+				}
+				if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+					return httpmock.NewStringResponse(400, err.Error()), nil
+				}
+				granted = payload.PermissionViewMenuIDs
+				return httpmock.NewStringResponse(200, `{"status": "success"}`), nil
+			})
+
+		// Mock the Superset API response for fetching role permissions, with a menu_access companion added by Superset
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/129/permissions/",
+			httpmock.NewStringResponder(200, `{ "result": [
+				{
+					"id": 240,
+					"permission_name": "database_access",
+					"view_menu_name": "[SelfPostgreSQL].(id:1)"
+				},
+				{
+					"id": 301,
+					"permission_name": "menu_access",
+					"view_menu_name": "SQL Lab"
+				}
+		]}`))
+
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig + `
+	resource "superset_role_permissions" "team" {
+	role_name            = "DWH-DB-Connect"
+	resource_permissions = [
+		{
+			permission = "database_access"
+			view_menu  = "[SelfPostgreSQL].(id:1)"
+		},
+	]
+	ignore_permissions = [
+		{
+			permission = "menu_access"
+			view_menu  = "/.*/"
+		},
+	]
+	}
+	`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("superset_role_permissions.team", "resource_permissions.#", "1"),
+						resource.TestCheckTypeSetElemNestedAttrs("superset_role_permissions.team", "resource_permissions.*", map[string]string{
+							"permission": "database_access",
+							"id":         "240",
+						}),
+						resource.TestCheckResourceAttr("superset_role_permissions.team", "unknown_permissions.#", "1"),
+						resource.TestCheckTypeSetElemNestedAttrs("superset_role_permissions.team", "unknown_permissions.*", map[string]string{
+							"permission": "menu_access",
+							"view_menu":  "SQL Lab",
+							"id":         "301",
+						}),
+					),
+				},
+			},
+		})
+
+		// Destroy keeps the ignored companion permission granted
+		if len(granted) != 1 || granted[0] != 301 {
+			t.Errorf("expected only the ignored permission 301 to stay granted after destroy, got %v", granted)
+		}
+	})
+
+	t.Run("KeepUnknownPermissions", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		// Mock the Superset API login response
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+			httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+		// Mock the Superset API response for fetching roles
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+			httpmock.NewStringResponder(200, `{
+				"result": [
+					{"id": 129, "name": "DWH-DB-Connect"}
+				]
+			}`))
+
+		// Mock the Superset API response for fetching permissions resources
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page:0,page_size:5000)",
+			httpmock.NewStringResponder(200, `{ "result": [
+				{
+					"id": 240,
+					"permission": {
+						"name": "database_access"
+					},
+					"view_menu": {
+						"name": "[SelfPostgreSQL].(id:1)"
+					}
+				}
+		]}`))
+
+		// Mock the Superset API response for updating role permissions, recording the granted IDs
+		var granted []int64
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/129/permissions",
+			func(req *http.Request) (*http.Response, error) {
+				var payload struct {
+					PermissionViewMenuIDs []int64 `json:"permission_view_menu_ids"`
+				}
+				if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+					return httpmock.NewStringResponse(400, err.Error()), nil
+				}
+				granted = payload.PermissionViewMenuIDs
+				return httpmock.NewStringResponse(200, `{"status": "success"}`), nil
+			})
+
+		// Mock the Superset API response for fetching role permissions, with a permission granted by hand
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/129/permissions/",
+			httpmock.NewStringResponder(200, `{ "result": [
+				{
+					"id": 240,
+					"permission_name": "database_access",
+					"view_menu_name": "[SelfPostgreSQL].(id:1)"
+				},
+				{
+					"id": 301,
+					"permission_name": "menu_access",
+					"view_menu_name": "SQL Lab"
+				}
+		]}`))
+
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig + `
+	resource "superset_role_permissions" "team" {
+	role_name            = "DWH-DB-Connect"
+	resource_permissions = [
+		{
+			permission = "database_access"
+			view_menu  = "[SelfPostgreSQL].(id:1)"
+		},
+	]
+	keep_unknown_permissions = true
+	}
+	`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("superset_role_permissions.team", "resource_permissions.#", "1"),
+						resource.TestCheckTypeSetElemNestedAttrs("superset_role_permissions.team", "resource_permissions.*", map[string]string{
+							"permission": "database_access",
+							"id":         "240",
+						}),
+						resource.TestCheckResourceAttr("superset_role_permissions.team", "unknown_permissions.#", "1"),
+						resource.TestCheckTypeSetElemNestedAttrs("superset_role_permissions.team", "unknown_permissions.*", map[string]string{
+							"permission": "menu_access",
+							"view_menu":  "SQL Lab",
+							"id":         "301",
+						}),
+					),
+				},
+			},
+		})
+
+		// Destroy keeps the permission granted by hand
+		if len(granted) != 1 || granted[0] != 301 {
+			t.Errorf("expected only the unknown permission 301 to stay granted after destroy, got %v", granted)
+		}
+	})
+
+	t.Run("BuiltInRole", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		// Mock the Superset API login response
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+			httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+		// Mock the Superset API responses for the Public role
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+			httpmock.NewStringResponder(200, `{"result": [{"id": 2, "name": "Public"}]}`))
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/2",
+			httpmock.NewStringResponder(200, `{"result": {"id": 2, "name": "Public"}}`))
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page:0,page_size:5000)",
+			httpmock.NewStringResponder(200, `{"result": [{"id": 50, "permission": {"name": "can_read"}, "view_menu": {"name": "Dashboard"}}]}`))
+
+		var granted []int64
+		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/2/permissions",
+			func(req *http.Request) (*http.Response, error) {
+				var body struct {
+					PermissionViewMenuIDs []int64 `json:"permission_view_menu_ids"`
+				}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return httpmock.NewStringResponse(400, err.Error()), nil
+				}
+				granted = body.PermissionViewMenuIDs
+				return httpmock.NewStringResponse(200, `{"status": "success"}`), nil
+			})
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/2/permissions/",
+			func(req *http.Request) (*http.Response, error) {
+				result := `{"result": []}`
+				if len(granted) > 0 {
+					result = `{"result": [{"id": 50, "permission_name": "can_read", "view_menu_name": "Dashboard"}]}`
+				}
+				return httpmock.NewStringResponse(200, result), nil
+			})
+
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				// Built-in roles are refused without the opt-in
+				{
+					Config:      providerConfig + testAccRolePermissionsPublicConfig(false),
+					ExpectError: regexp.MustCompile(`Built-In Role`),
+				},
+				// The opt-in lets the permissions of Public be managed
+				{
+					Config: providerConfig + testAccRolePermissionsPublicConfig(true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("superset_role_permissions.public", "allow_builtin_role", "true"),
+						resource.TestCheckResourceAttr("superset_role_permissions.public", "resource_permissions.#", "1"),
+					),
+				},
+			},
+		})
+
+		// Destroy clears the permissions of Public, which the opt-in allows
+		if len(granted) != 0 {
+			t.Errorf("expected the permissions of Public to be cleared on destroy, got %v", granted)
+		}
+	})
+}
+
+func testAccRolePermissionsPublicConfig(allowBuiltinRole bool) string {
+	return fmt.Sprintf(`
+resource "superset_role_permissions" "public" {
+  role_name          = "Public"
+  allow_builtin_role = %t
+  resource_permissions = [
+    {
+      permission = "can_read"
+      view_menu  = "Dashboard"
+    }
+  ]
+}
+`, allowBuiltinRole)
+}
+
+func TestKeptUnknownPermissions(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/129/permissions/",
+		httpmock.NewStringResponder(200, `{"result": [
+			{"id": 240, "permission_name": "database_access", "view_menu_name": "[SelfPostgreSQL].(id:1)"},
+			{"id": 250, "permission_name": "can_read", "view_menu_name": "Dashboard"},
+			{"id": 301, "permission_name": "menu_access", "view_menu_name": "SQL Lab"}
+		]}`))
+
+	supersetClient, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	permission := func(name, viewMenu string) resourcePermissionModel {
+		return resourcePermissionModel{Permission: types.StringValue(name), ViewMenu: types.StringValue(viewMenu)}
+	}
+
+	cases := map[string]struct {
+		previous *rolePermissionsResourceModel
+		expected []int64
+	}{
+		// Nothing is managed yet on create, so every granted permission is kept
+		"Create": {expected: []int64{240, 250, 301}},
+		// Permissions the previous state managed are revoked once removed from the plan
+		"Update": {
+			previous: &rolePermissionsResourceModel{
+				ResourcePermissions: []resourcePermissionModel{permission("can_read", "Dashboard")},
+				DatabaseAccess:      []databaseAccessModel{{DatabaseID: types.Int64Value(1)}},
+			},
+			expected: []int64{301},
+		},
+		// A permission merged into resource_permissions by an earlier refresh is still unknown
+		"MergedByRefresh": {
+			previous: &rolePermissionsResourceModel{
+				ResourcePermissions: []resourcePermissionModel{permission("can_read", "Dashboard"), permission("menu_access", "SQL Lab")},
+				UnknownPermissions:  []resourcePermissionModel{permission("menu_access", "SQL Lab")},
+			},
+			expected: []int64{240, 301},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kept, err := keptUnknownPermissions(supersetClient, 129, tc.previous)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []int64
+			for _, perm := range kept {
+				ids = append(ids, perm.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tc.expected) {
+				t.Errorf("expected %v to be kept, got %v", tc.expected, ids)
+			}
+		})
+	}
+}
+
+func TestWithBuiltInRoleHint(t *testing.T) {
+	refused := fmt.Errorf("refusing to clear the permissions of role \"Public\" (ID 2): it is a %w of Superset", client.ErrBuiltInRole)
+	err := withBuiltInRoleHint(refused)
+	if !errors.Is(err, client.ErrBuiltInRole) || !strings.Contains(err.Error(), "allow_builtin_role = true") {
+		t.Errorf("expected the refusal to name allow_builtin_role, got %v", err)
+	}
+
+	other := errors.New("failed to update role permissions, status code: 500")
+	if err := withBuiltInRoleHint(other); err != other {
+		t.Errorf("expected other errors unchanged, got %v", err)
+	}
+	if err := withBuiltInRoleHint(nil); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
 }
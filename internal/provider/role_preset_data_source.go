@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &rolePresetDataSource{}
+	_ datasource.DataSourceWithConfigure = &rolePresetDataSource{}
+)
+
+// NewRolePresetDataSource is a helper function to simplify the provider implementation.
+func NewRolePresetDataSource() datasource.DataSource {
+	return &rolePresetDataSource{}
+}
+
+// rolePresetDataSource is the data source implementation.
+type rolePresetDataSource struct {
+	client *client.Client
+}
+
+// rolePresetDataSourceModel maps the data source schema data.
+type rolePresetDataSourceModel struct {
+	Preset      types.String            `tfsdk:"preset"`
+	Permissions []presetPermissionModel `tfsdk:"permissions"`
+}
+
+// presetPermissionModel maps a preset permission, shaped like a superset_role_permissions resource_permissions entry.
+type presetPermissionModel struct {
+	Permission types.String `tfsdk:"permission"`
+	ViewMenu   types.String `tfsdk:"view_menu"`
+}
+
+// Metadata returns the data source type name.
+func (d *rolePresetDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_preset"
+}
+
+// Schema defines the schema for the data source.
+func (d *rolePresetDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the permission set of one of Superset's built-in roles, as synced by the running Superset version. " +
+			"The permissions can be combined with setunion/setsubtract to declare custom roles as a preset plus or minus deltas.",
+		Attributes: map[string]schema.Attribute{
+			"preset": schema.StringAttribute{
+				MarkdownDescription: "Name of the built-in role to use as preset. One of: " + strings.Join(client.BuiltInRoleNames, ", ") + ".",
+				Required:            true,
+			},
+			"permissions": schema.ListNestedAttribute{
+				MarkdownDescription: "Permissions of the built-in role, in the shape accepted by superset_role_permissions resource_permissions.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"permission": schema.StringAttribute{
+							MarkdownDescription: "The name of the permission.",
+							Computed:            true,
+						},
+						"view_menu": schema.StringAttribute{
+							MarkdownDescription: "The name of the view menu associated with the permission.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *rolePresetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state rolePresetDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	preset := state.Preset.ValueString()
+	if !slices.Contains(client.BuiltInRoleNames, preset) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("preset"),
+			"Unknown Role Preset",
+			fmt.Sprintf("%q is not a built-in Superset role. Expected one of: %s.", preset, strings.Join(client.BuiltInRoleNames, ", ")),
+		)
+		return
+	}
+
+	roleID, err := d.client.GetRoleIDByName(preset)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Find Role",
+			fmt.Sprintf("Unable to find built-in role %s: %s", preset, err.Error()),
+		)
+		return
+	}
+
+	permissions, err := d.client.GetRolePermissions(roleID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Superset Role Permissions",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, perm := range permissions {
+		state.Permissions = append(state.Permissions, presetPermissionModel{
+			Permission: types.StringValue(perm.PermissionName),
+			ViewMenu:   types.StringValue(perm.ViewMenuName),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *rolePresetDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccRolePresetDataSource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for getting role ID by name
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 4, "name": "Gamma"}]}`))
+
+	// Mock the Superset API response for getting the built-in role permissions
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/4/permissions/",
+		httpmock.NewStringResponder(200, `{
+			"result": [
+				{"id": 10, "permission_name": "can_read", "view_menu_name": "Dashboard"},
+				{"id": 11, "permission_name": "menu_access", "view_menu_name": "Dashboards"}
+			]
+		}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccRolePresetDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_role_preset.gamma", "preset", "Gamma"),
+					resource.TestCheckResourceAttr("data.superset_role_preset.gamma", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("data.superset_role_preset.gamma", "permissions.0.permission", "can_read"),
+					resource.TestCheckResourceAttr("data.superset_role_preset.gamma", "permissions.0.view_menu", "Dashboard"),
+					resource.TestCheckResourceAttr("data.superset_role_preset.gamma", "permissions.1.permission", "menu_access"),
+					resource.TestCheckResourceAttr("data.superset_role_preset.gamma", "permissions.1.view_menu", "Dashboards"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRolePresetDataSourceConfig = `
+data "superset_role_preset" "gamma" {
+  preset = "Gamma"
+}
+`
@@ -2,14 +2,18 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,6 +26,7 @@ var (
 	_ resource.Resource                = &roleResource{}
 	_ resource.ResourceWithConfigure   = &roleResource{}
 	_ resource.ResourceWithImportState = &roleResource{}
+	_ resource.ResourceWithModifyPlan  = &roleResource{}
 )
 
 // NewRoleResource is a helper function to simplify the provider implementation.
@@ -38,7 +43,10 @@ type roleResource struct {
 type roleResourceModel struct {
 	ID          types.Int64  `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
+	Users       types.List   `tfsdk:"users"`
 	LastUpdated types.String `tfsdk:"last_updated"`
+
+	AllowOutsidePrefix types.Bool `tfsdk:"allow_outside_prefix"`
 }
 
 // Metadata returns the resource type name.
@@ -49,33 +57,73 @@ func (r *roleResource) Metadata(_ context.Context, req resource.MetadataRequest,
 // Schema defines the schema for the resource.
 func (r *roleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages a role in Superset.",
+		MarkdownDescription: "Manages a role in Superset. Its permissions are managed with `superset_role_permissions` and its users with `superset_role_users`.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
-				Description: "Numeric identifier of the role.",
-				Computed:    true,
+				MarkdownDescription: "Numeric identifier of the role.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
 			"name": schema.StringAttribute{
-				Description: "Name of the role.",
-				Required:    true,
+				MarkdownDescription: "Name of the role, e.g. \"Analytics-Readers\". Must start with the provider's `managed_role_prefix` when one is set, unless `allow_outside_prefix` is set.",
+				Required:            true,
+			},
+			"users": schema.ListAttribute{
+				MarkdownDescription: "Usernames of the users currently assigned to the role, sorted by name. Null, with a warning, when the Superset version does not serve the users of roles.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
-				Description: "Timestamp of the last update.",
-				Computed:    true,
+				MarkdownDescription: "Timestamp of the last update.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"allow_outside_prefix": schema.BoolAttribute{
+				MarkdownDescription: "Manage the role even though its name does not start with the provider's `managed_role_prefix`. Defaults to false.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
+// ModifyPlan refuses roles named outside the provider's managed_role_prefix, whether they are created,
+// renamed or imported, unless allow_outside_prefix is set.
+func (r *roleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil || r.client.ManagedRolePrefix == "" {
+		return
+	}
+
+	var plan roleResourceModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &plan.Name)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_outside_prefix"), &plan.AllowOutsidePrefix)...)
+	if resp.Diagnostics.HasError() || plan.Name.IsUnknown() || plan.AllowOutsidePrefix.IsUnknown() {
+		return
+	}
+
+	if !strings.HasPrefix(plan.Name.ValueString(), r.client.ManagedRolePrefix) && !plan.AllowOutsidePrefix.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Role Outside Managed Prefix",
+			fmt.Sprintf("Role %q does not start with %q, the managed_role_prefix of the provider. "+
+				"Rename the role, or set allow_outside_prefix = true to manage it anyway.", plan.Name.ValueString(), r.client.ManagedRolePrefix),
+		)
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_role", &resp.Diagnostics) {
+		return
+	}
+
 	var plan roleResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -86,16 +134,45 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	id, err := r.client.CreateRole(plan.Name.ValueString())
+	id, created, err := r.client.CreateRole(plan.Name.ValueString())
+	if err != nil {
+		addRequestError(&resp.Diagnostics, "Unable to Create Superset Role", "CreateRole", err, roleRequestFields)
+		return
+	}
+
+	// A role that already existed is left in place when a follow-up read fails.
+	removeCreated := func() {
+		if created {
+			removePartiallyCreated(ctx, r.client, &resp.Diagnostics, "role", id, (*client.Client).DeleteRole)
+		}
+	}
+
+	// Superset may serve reads from a lagging replica, so wait until the new role is readable.
+	err = waitForCreated(ctx, r.client, func() error {
+		_, err := r.client.GetRole(id)
+		return err
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Superset Role",
-			fmt.Sprintf("CreateRole failed: %s", err.Error()),
+			"Unable to Read Created Superset Role",
+			fmt.Sprintf("Role ID %d was created but could not be read back: %s", id, err.Error()),
 		)
+		removeCreated()
+		return
+	}
+
+	users, err := readRoleUsers(ctx, r.client, id, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Superset Role Users",
+			fmt.Sprintf("GetRoleUsers failed for role ID %d: %s", id, err.Error()),
+		)
+		removeCreated()
 		return
 	}
 
 	plan.ID = types.Int64Value(id)
+	plan.Users = users
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
 
 	diags = resp.State.Set(ctx, &plan)
@@ -110,6 +187,33 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 	tflog.Debug(ctx, fmt.Sprintf("Created role: ID=%d, Name=%s", plan.ID.ValueInt64(), plan.Name.ValueString()))
 }
 
+// readRoleUsers returns the usernames of the role as a list. The role has just been read, so a 404 or
+// 405 comes from the users endpoint, which older Superset versions do not serve: the list is then null,
+// with a warning, since the users are only reported and never managed by the resource.
+func readRoleUsers(ctx context.Context, c *client.Client, id int64, diags *diag.Diagnostics) (types.List, error) {
+	users, err := c.GetRoleUsers(id)
+	if errors.Is(err, client.ErrNotFound) || errors.Is(err, errors.ErrUnsupported) {
+		diags.AddAttributeWarning(
+			path.Root("users"),
+			"Role Users Unavailable",
+			fmt.Sprintf("Superset does not list the users of role ID %d (%s), so users is left null.", id, err),
+		)
+		return types.ListNull(types.StringType), nil
+	}
+	if err != nil {
+		return types.ListNull(types.StringType), err
+	}
+
+	list, listDiags := types.ListValueFrom(ctx, types.StringType, users)
+	diags.Append(listDiags...)
+	return list, nil
+}
+
+// roleRequestFields maps the fields of the create/update request body to the attributes they are set from.
+var roleRequestFields = map[string]path.Path{
+	"name": path.Root("name"),
+}
+
 // Read refreshes the Terraform state with the latest data from Superset.
 func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Starting Read method")
@@ -144,8 +248,18 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		})
 	}
 
+	users, err := readRoleUsers(ctx, r.client, state.ID.ValueInt64(), &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading role users",
+			fmt.Sprintf("Could not read users of role ID %d: %s", state.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
 	// Assuming role.Name is a string and needs to be converted to types.String
 	state.Name = types.StringValue(role.Name)
+	state.Users = users
 
 	// Save updated state
 	diags = resp.State.Set(ctx, &state)
@@ -161,6 +275,10 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "Starting Update method")
+	if refuseInReadOnlyMode(r.client, "update", "superset_role", &resp.Diagnostics) {
+		return
+	}
+
 	var plan roleResourceModel
 	var state roleResourceModel
 
@@ -171,12 +289,13 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		// Only update if there is a real change
 		err := r.client.UpdateRole(state.ID.ValueInt64(), plan.Name.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to update role", "Error: "+err.Error())
+			addRequestError(&resp.Diagnostics, "Failed to update role", "UpdateRole", err, roleRequestFields)
 			return
 		}
 		state.Name = plan.Name
 		state.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
 	}
+	state.AllowOutsidePrefix = plan.AllowOutsidePrefix
 
 	resp.State.Set(ctx, &state)
 	tflog.Debug(ctx, fmt.Sprintf("Updated role: ID=%d, Name=%s", state.ID.ValueInt64(), state.Name.ValueString()))
@@ -185,6 +304,10 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Starting Delete method")
+	if refuseInReadOnlyMode(r.client, "delete", "superset_role", &resp.Diagnostics) {
+		return
+	}
+
 	var state roleResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
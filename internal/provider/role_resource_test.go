@@ -1,10 +1,15 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/jarcoal/httpmock"
+	"terraform-provider-superset/internal/client"
 )
 
 func TestAccRoleResource(t *testing.T) {
@@ -28,6 +33,10 @@ func TestAccRoleResource(t *testing.T) {
 	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1",
 		httpmock.NewStringResponder(200, `{"result": {"id": 1, "name": "Antifraud"}}`))
 
+	// Mock the Superset API response for reading the users of the role
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1/users",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 7, "username": "jdoe"}, {"id": 3, "username": "alice"}]}`))
+
 	// Mock the Superset API response for deleting roles
 	httpmock.RegisterResponder("DELETE", "http://superset-host/api/v1/security/roles/1",
 		httpmock.NewStringResponder(204, ""))
@@ -43,6 +52,9 @@ func TestAccRoleResource(t *testing.T) {
 					resource.TestCheckResourceAttr("superset_role.team_antifraud", "name", "Antifraud"),
 					resource.TestCheckResourceAttrSet("superset_role.team_antifraud", "id"),
 					resource.TestCheckResourceAttrSet("superset_role.team_antifraud", "last_updated"),
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "users.#", "2"),
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "users.0", "alice"),
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "users.1", "jdoe"),
 				),
 			},
 			// ImportState testing
@@ -61,3 +73,326 @@ resource "superset_role" "team_antifraud" {
   name = "Antifraud"
 }
 `
+
+func TestAccRoleResourceReadOnly(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create is refused without calling the roles endpoint
+			{
+				Config:      testAccReadOnlyProviderConfig + testAccRoleResourceConfig,
+				ExpectError: regexp.MustCompile(`Provider Is Read-Only`),
+			},
+		},
+	})
+
+	if calls := httpmock.GetCallCountInfo()["POST http://superset-host/api/v1/security/roles/"]; calls != 0 {
+		t.Errorf("expected no role to be created in read-only mode, got %d create calls", calls)
+	}
+}
+
+const testAccReadOnlyProviderConfig = `
+provider "superset" {
+  host      = "http://superset-host"
+  username  = "fake-username"
+  password  = "fake-password"
+  read_only = true
+}
+`
+
+func TestAccRoleResourceReadAfterCreate(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for creating roles
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/",
+		httpmock.NewStringResponder(201, `{"id": 1, "name": "Antifraud"}`))
+
+	// Mock a lagging read replica: the new role is not found on the first two reads
+	reads := 0
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1",
+		func(req *http.Request) (*http.Response, error) {
+			reads++
+			if reads <= 2 {
+				return httpmock.NewStringResponse(404, `{"message": "Not found"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"result": {"id": 1, "name": "Antifraud"}}`), nil
+		})
+
+	// Mock the Superset API response for reading the users of the role
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1/users",
+		httpmock.NewStringResponder(200, `{"result": []}`))
+
+	// Mock the Superset API response for deleting roles
+	httpmock.RegisterResponder("DELETE", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(204, ""))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create succeeds once the role becomes readable
+			{
+				Config: testAccRetryProviderConfig + testAccRoleResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "id", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRetryProviderConfig = `
+provider "superset" {
+  host                       = "http://superset-host"
+  username                   = "fake-username"
+  password                   = "fake-password"
+  create_read_retry_attempts = 3
+  create_read_retry_delay    = "0s"
+}
+`
+
+func TestAccRoleResourcePartialCreate(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for creating roles
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/",
+		httpmock.NewStringResponder(201, `{"id": 1, "name": "Antifraud"}`))
+
+	// Mock the Superset API response for reading the created role
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(200, `{"result": {"id": 1, "name": "Antifraud"}}`))
+
+	// Mock a failure of the follow-up read of the role users
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1/users",
+		httpmock.NewStringResponder(500, `{"message": "Internal error"}`))
+
+	// Mock the Superset API response for deleting roles
+	httpmock.RegisterResponder("DELETE", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(204, ""))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The create fails and the half configured role is deleted again
+			{
+				Config:      providerConfig + testAccRoleResourceConfig,
+				ExpectError: regexp.MustCompile("Unable to Read Superset Role Users"),
+			},
+		},
+	})
+
+	if deletes := httpmock.GetCallCountInfo()["DELETE http://superset-host/api/v1/security/roles/1"]; deletes != 1 {
+		t.Errorf("expected the partially created role to be deleted once, got %d deletes", deletes)
+	}
+}
+
+func TestAccRoleResourceExistingReadFails(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock a role of the same name that already exists
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "name": "Antifraud"}]}`))
+
+	// Mock the Superset API response for reading the role
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(200, `{"result": {"id": 1, "name": "Antifraud"}}`))
+
+	// Mock a failure of the follow-up read of the role users
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1/users",
+		httpmock.NewStringResponder(500, `{"message": "Internal error"}`))
+
+	// Mock the Superset API response for deleting roles
+	httpmock.RegisterResponder("DELETE", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(204, ""))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The create fails, but the role it found is not the provider's to delete
+			{
+				Config:      providerConfig + testAccRoleResourceConfig,
+				ExpectError: regexp.MustCompile("Unable to Read Superset Role Users"),
+			},
+		},
+	})
+
+	if posts := httpmock.GetCallCountInfo()["POST http://superset-host/api/v1/security/roles/"]; posts != 0 {
+		t.Errorf("expected the existing role to be adopted, got %d creates", posts)
+	}
+	if deletes := httpmock.GetCallCountInfo()["DELETE http://superset-host/api/v1/security/roles/1"]; deletes != 0 {
+		t.Errorf("expected the existing role to be kept, got %d deletes", deletes)
+	}
+}
+
+func TestAccRoleResourceUsersUnavailable(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for creating roles
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/",
+		httpmock.NewStringResponder(201, `{"id": 1, "name": "Antifraud"}`))
+
+	// Mock the Superset API response for reading roles by ID
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(200, `{"result": {"id": 1, "name": "Antifraud"}}`))
+
+	// Mock a Superset version without the endpoint listing the users of a role
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1/users",
+		httpmock.NewStringResponder(405, `{"message": "Method Not Allowed"}`))
+
+	// Mock the Superset API response for deleting roles
+	httpmock.RegisterResponder("DELETE", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(204, ""))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The role is kept, with its users left null
+			{
+				Config: providerConfig + testAccRoleResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "id", "1"),
+					resource.TestCheckNoResourceAttr("superset_role.team_antifraud", "users"),
+				),
+			},
+		},
+	})
+
+	if deletes := httpmock.GetCallCountInfo()["DELETE http://superset-host/api/v1/security/roles/1"]; deletes != 1 {
+		t.Errorf("expected the role to be deleted only at destroy, got %d deletes", deletes)
+	}
+}
+
+func TestReadRoleUsers(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	c, err := client.NewClient("http://superset-host", "fake-username", "fake-password", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		status   int
+		null     bool
+		warnings int
+		wantErr  bool
+	}{
+		"Listed":           {status: 200},
+		"NotFound":         {status: 404, null: true, warnings: 1},
+		"MethodNotAllowed": {status: 405, null: true, warnings: 1},
+		"ServerError":      {status: 500, null: true, wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1/users",
+				httpmock.NewStringResponder(tc.status, `{"result": [{"id": 7, "username": "jdoe"}]}`))
+
+			var diags diag.Diagnostics
+			users, err := readRoleUsers(context.Background(), c, 1, &diags)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if users.IsNull() != tc.null || diags.WarningsCount() != tc.warnings {
+				t.Errorf("expected null %v with %d warnings, got %v with %v", tc.null, tc.warnings, users, diags)
+			}
+		})
+	}
+}
+
+func TestAccRoleResourceManagedRolePrefix(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API responses for the role created with the override
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/roles/",
+		httpmock.NewStringResponder(201, `{"id": 1, "name": "Antifraud"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(200, `{"result": {"id": 1, "name": "Antifraud"}}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/1/users",
+		httpmock.NewStringResponder(200, `{"result": []}`))
+	httpmock.RegisterResponder("DELETE", "http://superset-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(204, ""))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A role outside the prefix is refused at plan time
+			{
+				Config:      testAccManagedRolePrefixProviderConfig + testAccRoleResourceConfig,
+				ExpectError: regexp.MustCompile(`Role Outside Managed Prefix`),
+			},
+			// The override lets it be managed
+			{
+				Config: testAccManagedRolePrefixProviderConfig + `
+resource "superset_role" "team_antifraud" {
+  name                 = "Antifraud"
+  allow_outside_prefix = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "name", "Antifraud"),
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "allow_outside_prefix", "true"),
+				),
+			},
+		},
+	})
+
+	if calls := httpmock.GetCallCountInfo()["POST http://superset-host/api/v1/security/roles/"]; calls != 1 {
+		t.Errorf("expected only the overridden role to be created, got %d create calls", calls)
+	}
+}
+
+const testAccManagedRolePrefixProviderConfig = `
+provider "superset" {
+  host                = "http://superset-host"
+  username            = "fake-username"
+  password            = "fake-password"
+  managed_role_prefix = "tf-"
+}
+`
@@ -0,0 +1,383 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &roleUsersResource{}
+	_ resource.ResourceWithConfigure   = &roleUsersResource{}
+	_ resource.ResourceWithImportState = &roleUsersResource{}
+)
+
+// NewRoleUsersResource is a helper function to simplify the provider implementation.
+func NewRoleUsersResource() resource.Resource {
+	return &roleUsersResource{}
+}
+
+// roleUsersResource is the resource implementation.
+type roleUsersResource struct {
+	client *client.Client
+}
+
+// roleUsersResourceModel maps the resource schema data.
+type roleUsersResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	RoleName    types.String `tfsdk:"role_name"`
+	Usernames   types.Set    `tfsdk:"usernames"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *roleUsersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_users"
+}
+
+// Schema defines the schema for the resource.
+func (r *roleUsersResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the authoritative set of users assigned to a role in Superset. " +
+			"Users added to the role outside Terraform are removed on the next apply. " +
+			"Destroying the resource removes every user from the role, which is refused for the built-in roles unless " +
+			"`allow_builtin_role_changes` is set, and for roles outside the `managed_role_prefix` of the provider.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the role users resource, the role ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the role to which the users are assigned.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"usernames": schema.SetAttribute{
+				MarkdownDescription: "Usernames of the users assigned to the role.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"last_updated": schema.StringAttribute{
+				MarkdownDescription: "The timestamp of the last update to the role users.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Create assigns the users to the role and sets the initial Terraform state.
+func (r *roleUsersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Starting Create method")
+	if refuseInReadOnlyMode(r.client, "create", "superset_role_users", &resp.Diagnostics) {
+		return
+	}
+
+	var plan roleUsersResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		tflog.Debug(ctx, "Exiting Create due to error in retrieving plan", map[string]interface{}{
+			"diagnostics": resp.Diagnostics,
+		})
+		return
+	}
+
+	supersetClient := r.client.WithContext(ctx)
+
+	roleID, err := supersetClient.GetRoleIDByName(plan.RoleName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error finding role",
+			fmt.Sprintf("Could not find role '%s': %s", plan.RoleName.ValueString(), err),
+		)
+		return
+	}
+
+	r.applyUsers(ctx, supersetClient, roleID, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(roleID, 10))
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		tflog.Debug(ctx, "Exiting Create due to error in setting state", map[string]interface{}{
+			"diagnostics": resp.Diagnostics,
+		})
+		return
+	}
+
+	tflog.Debug(ctx, "Create method completed successfully")
+}
+
+// Read refreshes the Terraform state with the users currently assigned to the role.
+func (r *roleUsersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Starting Read method")
+
+	var state roleUsersResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		tflog.Debug(ctx, "Exiting Read due to error in getting state", map[string]interface{}{
+			"diagnostics": resp.Diagnostics,
+		})
+		return
+	}
+
+	roleID, err := strconv.ParseInt(state.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing role ID", fmt.Sprintf("Could not parse role ID '%s': %s", state.ID.ValueString(), err))
+		return
+	}
+
+	supersetClient := r.client.WithContext(ctx)
+	users, err := supersetClient.GetRoleUsers(roleID)
+	if errors.Is(err, client.ErrNotFound) {
+		// Superset versions without the users endpoint answer 404 as well, so the role itself is checked
+		// before the resource is dropped.
+		_, roleErr := supersetClient.GetRole(roleID)
+		if errors.Is(roleErr, client.ErrNotFound) {
+			tflog.Debug(ctx, fmt.Sprintf("Role ID %d not found, removing from state", roleID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if roleErr != nil {
+			resp.Diagnostics.AddError(
+				"Error reading role",
+				fmt.Sprintf("Could not read role ID %d: %s", roleID, roleErr),
+			)
+			return
+		}
+	}
+	if errors.Is(err, client.ErrNotFound) || errors.Is(err, errors.ErrUnsupported) {
+		resp.Diagnostics.AddError(
+			"Role Users Unavailable",
+			fmt.Sprintf("Superset does not list the users of role ID %d (%s), which superset_role_users requires to manage them.", roleID, err),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading role users",
+			fmt.Sprintf("Could not read users of role ID %d: %s", roleID, err),
+		)
+		return
+	}
+
+	state.Usernames, diags = types.SetValueFrom(ctx, types.StringType, users)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		tflog.Debug(ctx, "Exiting Read due to error in setting state", map[string]interface{}{
+			"diagnostics": resp.Diagnostics,
+		})
+		return
+	}
+}
+
+// Update replaces the users assigned to the role and sets the updated Terraform state on success.
+func (r *roleUsersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Starting Update method")
+	if refuseInReadOnlyMode(r.client, "update", "superset_role_users", &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state roleUsersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID, err := strconv.ParseInt(state.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing role ID", fmt.Sprintf("Could not parse role ID '%s': %s", state.ID.ValueString(), err))
+		return
+	}
+
+	r.applyUsers(ctx, r.client.WithContext(ctx), roleID, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Update method completed successfully")
+}
+
+// Delete removes every user from the role and removes the Terraform state on success.
+func (r *roleUsersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Starting Delete method")
+	if refuseInReadOnlyMode(r.client, "delete", "superset_role_users", &resp.Diagnostics) {
+		return
+	}
+
+	var state roleUsersResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		tflog.Debug(ctx, "Exiting Delete due to error in getting state", map[string]interface{}{
+			"diagnostics": resp.Diagnostics,
+		})
+		return
+	}
+
+	roleID, err := strconv.ParseInt(state.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing role ID", fmt.Sprintf("Could not parse role ID '%s': %s", state.ID.ValueString(), err))
+		return
+	}
+
+	supersetClient := r.client.WithContext(ctx)
+	role, err := supersetClient.GetRole(roleID)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading role",
+			fmt.Sprintf("Could not read role ID %d: %s", roleID, err),
+		)
+		return
+	}
+	checkClearedRole(supersetClient, role, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = supersetClient.UpdateRoleUsers(roleID, nil)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error clearing role users",
+			fmt.Sprintf("Could not remove the users of role ID %d: %s", roleID, err),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+	tflog.Debug(ctx, "Delete method completed successfully")
+}
+
+// checkClearedRole reports the roles whose users Delete must not remove: the built-in roles, whose users
+// keep Superset administered, unless allow_builtin_role_changes is set, and the roles outside the
+// managed_role_prefix of the provider.
+func checkClearedRole(c *client.Client, role *client.Role, diags *diag.Diagnostics) {
+	if slices.Contains(client.BuiltInRoleNames, role.Name) && !c.AllowBuiltInRoleChanges {
+		diags.AddAttributeError(
+			path.Root("role_name"),
+			"Built-In Role",
+			fmt.Sprintf("%q is a %s of Superset, whose users the delete would remove. "+
+				"Remove the resource from the state with `terraform state rm` to keep them, or set allow_builtin_role_changes in the provider configuration to remove them anyway.", role.Name, client.ErrBuiltInRole),
+		)
+	}
+	if c.ManagedRolePrefix != "" && !strings.HasPrefix(role.Name, c.ManagedRolePrefix) {
+		diags.AddAttributeError(
+			path.Root("role_name"),
+			"Role Outside Managed Prefix",
+			fmt.Sprintf("Role %q does not start with %q, the managed_role_prefix of the provider, so its users are not removed. "+
+				"Remove the resource from the state with `terraform state rm` to keep them.", role.Name, c.ManagedRolePrefix),
+		)
+	}
+}
+
+// applyUsers resolves the planned usernames and makes them the only users of the role.
+func (r *roleUsersResource) applyUsers(ctx context.Context, supersetClient *client.Client, roleID int64, plan *roleUsersResourceModel, diags *diag.Diagnostics) {
+	var usernames []string
+	diags.Append(plan.Usernames.ElementsAs(ctx, &usernames, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	userIDs, err := supersetClient.GetUserIDsByUsername(usernames)
+	if err != nil {
+		diags.AddError(
+			"Error finding users",
+			fmt.Sprintf("Could not resolve the users of role '%s': %s", plan.RoleName.ValueString(), err),
+		)
+		return
+	}
+
+	ids := make([]int64, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	err = supersetClient.UpdateRoleUsers(roleID, ids)
+	if err != nil {
+		diags.AddError(
+			"Error updating role users",
+			fmt.Sprintf("Could not assign users to role '%s': %s", plan.RoleName.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports the resource state from the role ID.
+func (r *roleUsersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, "Starting ImportState method", map[string]interface{}{
+		"import_id": req.ID,
+	})
+
+	roleID, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing role ID", fmt.Sprintf("Could not parse role ID '%s': %s", req.ID, err))
+		return
+	}
+
+	role, err := r.client.WithContext(ctx).GetRole(roleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching role", fmt.Sprintf("Could not fetch role with ID '%d': %s", roleID, err))
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_name"), role.Name)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *roleUsersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
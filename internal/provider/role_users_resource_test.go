@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"terraform-provider-superset/internal/client"
+)
+
+func TestAccRoleUsersResource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for finding the role by name
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 12, "name": "Finance-Editors"}]}`))
+
+	// Mock the Superset API response for reading the role by ID
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/12",
+		httpmock.NewStringResponder(200, `{"result": {"id": 12, "name": "Finance-Editors"}}`))
+
+	// Mock the Superset API response for listing users
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/users/?q=(columns:!(id,username),page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "username": "alice"}, {"id": 2, "username": "bob"}, {"id": 3, "username": "carol"}]}`))
+
+	// The role keeps the users last assigned to it
+	usernames := map[int64]string{1: "alice", 2: "bob", 3: "carol"}
+	var assigned []int64
+	httpmock.RegisterResponder("PUT", "http://superset-host/api/v1/security/roles/12/users",
+		func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				UserIDs []int64 `json:"user_ids"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				return httpmock.NewStringResponse(400, err.Error()), nil
+			}
+			assigned = payload.UserIDs
+			return httpmock.NewStringResponse(200, `{"result": {}}`), nil
+		})
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/12/users",
+		func(req *http.Request) (*http.Response, error) {
+			users := []map[string]interface{}{}
+			for _, id := range assigned {
+				users = append(users, map[string]interface{}{"id": id, "username": usernames[id]})
+			}
+			return httpmock.NewJsonResponse(200, map[string]interface{}{"result": users})
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccRoleUsersResourceConfig(`"alice", "bob"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_role_users.finance", "id", "12"),
+					resource.TestCheckResourceAttr("superset_role_users.finance", "usernames.#", "2"),
+					resource.TestCheckTypeSetElemAttr("superset_role_users.finance", "usernames.*", "alice"),
+					resource.TestCheckTypeSetElemAttr("superset_role_users.finance", "usernames.*", "bob"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "superset_role_users.finance",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update replaces the users of the role
+			{
+				Config: providerConfig + testAccRoleUsersResourceConfig(`"carol"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_role_users.finance", "usernames.#", "1"),
+					resource.TestCheckTypeSetElemAttr("superset_role_users.finance", "usernames.*", "carol"),
+				),
+			},
+		},
+	})
+
+	if len(assigned) != 0 {
+		t.Errorf("expected destroy to remove every user from the role, got %v", assigned)
+	}
+}
+
+func testAccRoleUsersResourceConfig(usernames string) string {
+	return fmt.Sprintf(`
+resource "superset_role_users" "finance" {
+  role_name = "Finance-Editors"
+  usernames = [%s]
+}
+`, usernames)
+}
+
+func TestCheckClearedRole(t *testing.T) {
+	cases := map[string]struct {
+		role    string
+		allow   bool
+		prefix  string
+		wantErr bool
+	}{
+		"ManagedRole":     {role: "Finance-Editors"},
+		"BuiltInRole":     {role: "Admin", wantErr: true},
+		"AllowedBuiltIn":  {role: "Admin", allow: true},
+		"InsidePrefix":    {role: "Finance-Editors", prefix: "Finance-"},
+		"OutsidePrefix":   {role: "Sales-Editors", prefix: "Finance-", wantErr: true},
+		"BuiltInPrefixed": {role: "Gamma", allow: true, prefix: "Finance-", wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &client.Client{AllowBuiltInRoleChanges: tc.allow, ManagedRolePrefix: tc.prefix}
+			var diags diag.Diagnostics
+			checkClearedRole(c, &client.Role{ID: 1, Name: tc.role}, &diags)
+			if diags.HasError() != tc.wantErr {
+				t.Errorf("expected error %t, got %v", tc.wantErr, diags)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -28,13 +29,17 @@ type rolesDataSource struct {
 
 // rolesDataSourceModel maps the data source schema data.
 type rolesDataSourceModel struct {
-	Roles []roleModel `tfsdk:"roles"`
+	ID    types.String `tfsdk:"id"`
+	Roles []roleModel  `tfsdk:"roles"`
 }
 
 // roleModel maps the role schema data.
 type roleModel struct {
-	ID   types.Int64  `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	ID              types.Int64  `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	PermissionCount types.Int64  `tfsdk:"permission_count"`
+	UserCount       types.Int64  `tfsdk:"user_count"`
+	Users           []string     `tfsdk:"users"`
 }
 
 // Metadata returns the data source type name.
@@ -45,20 +50,39 @@ func (d *rolesDataSource) Metadata(_ context.Context, req datasource.MetadataReq
 // Schema defines the schema for the data source.
 func (d *rolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Fetches the list of roles from Superset.",
+		MarkdownDescription: "Fetches the list of roles from Superset, with the number of permissions and the users of each role." +
+			" On Superset versions before 5.0, which lack the role search endpoint, `permission_count` is null rather than fetched with one request per role." +
+			" When the users cannot be listed, e.g. because the provider account may not list users, `user_count` and `users` are null.",
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the data source, derived from the Superset host and the IDs of the listed roles, so it only changes when they do.",
+				Computed:            true,
+			},
 			"roles": schema.ListNestedAttribute{
-				Description: "List of roles.",
-				Computed:    true,
+				MarkdownDescription: "List of roles.",
+				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.Int64Attribute{
-							Description: "Numeric identifier of the role.",
-							Computed:    true,
+							MarkdownDescription: "Numeric identifier of the role.",
+							Computed:            true,
 						},
 						"name": schema.StringAttribute{
-							Description: "Name of the role.",
-							Computed:    true,
+							MarkdownDescription: "Name of the role.",
+							Computed:            true,
+						},
+						"permission_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of permissions assigned to the role, null when Superset does not list them.",
+							Computed:            true,
+						},
+						"user_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of users assigned to the role, null when the users cannot be listed.",
+							Computed:            true,
+						},
+						"users": schema.ListAttribute{
+							MarkdownDescription: "Usernames of the users assigned to the role, sorted by name, null when the users cannot be listed.",
+							ElementType:         types.StringType,
+							Computed:            true,
 						},
 					},
 				},
@@ -71,7 +95,7 @@ func (d *rolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state rolesDataSourceModel
 
-	roles, err := d.client.FetchRoles()
+	roles, count, withPermissions, err := d.client.FetchRoles()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Superset Roles",
@@ -79,13 +103,47 @@ func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		)
 		return
 	}
+	warnIfTruncated(&resp.Diagnostics, "roles", len(roles), count)
+	if !withPermissions {
+		resp.Diagnostics.AddWarning(
+			"Role Permissions Unavailable",
+			"Superset did not list the permissions of the roles, which needs the role search endpoint of Superset 5.0, so permission_count is left null. "+
+				"Use the superset_role_permissions data source for the roles whose permissions are needed.",
+		)
+	}
+
+	// Listing the users needs more access than listing the roles, so the users are only reported when available.
+	usersByRole, err := d.client.FetchRoleUsernames()
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Role Users Unavailable",
+			fmt.Sprintf("The users of the roles could not be listed, so user_count and users are left null: %s", err),
+		)
+	}
+
+	for _, role := range roles {
+		model := roleModel{
+			ID:              types.Int64Value(role.ID),
+			Name:            types.StringValue(role.Name),
+			PermissionCount: types.Int64Null(),
+			UserCount:       types.Int64Null(),
+		}
+		if withPermissions {
+			model.PermissionCount = types.Int64Value(int64(len(role.Permissions)))
+		}
+		if usersByRole != nil {
+			model.Users = append([]string{}, usersByRole[role.ID]...)
+			sort.Strings(model.Users)
+			model.UserCount = types.Int64Value(int64(len(model.Users)))
+		}
+		state.Roles = append(state.Roles, model)
+	}
 
+	var ids []int64
 	for _, role := range roles {
-		state.Roles = append(state.Roles, roleModel{
-			ID:   types.Int64Value(role.ID),
-			Name: types.StringValue(role.Name),
-		})
+		ids = append(ids, role.ID)
 	}
+	state.ID = listDataSourceID(d.client.Host, "roles", ids)
 
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -16,23 +17,28 @@ func TestAccRolesDataSource(t *testing.T) {
 	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
 		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
 
-	// Mock the Superset API response for fetching roles
-	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+	// Mock the Superset API response for searching roles, the only one returning their permissions
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/search/?q=(page_size:5000)",
 		httpmock.NewStringResponder(200, `{
+			"count": 10,
 			"result": [
-				{"id": 1, "name": "Admin"},
-				{"id": 2, "name": "Public"},
-				{"id": 3, "name": "Alpha"},
-				{"id": 4, "name": "Gamma"},
-				{"id": 5, "name": "sql_lab"},
-				{"id": 38, "name": "Trino_Table-Role"},
-				{"id": 71, "name": "Custom-DWH"},
-				{"id": 73, "name": "Role for DWH"},
-				{"id": 555, "name": "Toronto-Team-Role"},
-				{"id": 129, "name": "DWH-DB-Connect"}
+				{"id": 1, "name": "Admin", "permission_ids": [1, 2, 3], "user_ids": [1]},
+				{"id": 2, "name": "Public", "permission_ids": [], "user_ids": []},
+				{"id": 3, "name": "Alpha", "permission_ids": []},
+				{"id": 4, "name": "Gamma", "permission_ids": []},
+				{"id": 5, "name": "sql_lab", "permission_ids": []},
+				{"id": 38, "name": "Trino_Table-Role", "permission_ids": []},
+				{"id": 71, "name": "Custom-DWH", "permission_ids": []},
+				{"id": 73, "name": "Role for DWH", "permission_ids": []},
+				{"id": 555, "name": "Toronto-Team-Role", "permission_ids": []},
+				{"id": 129, "name": "DWH-DB-Connect", "permission_ids": []}
 			]
 		}`))
 
+	// Mock the Superset API response for fetching the users with their roles
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/users/?q=(columns:!(id,username,roles.id),page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "username": "admin", "roles": [{"id": 1}]}]}`))
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -41,11 +47,19 @@ func TestAccRolesDataSource(t *testing.T) {
 			{
 				Config: providerConfig + testAccRolesDataSourceConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_roles.test", "id", listDataSourceID("http://superset-host", "roles", []int64{1, 2, 3, 4, 5, 38, 71, 73, 555, 129}).ValueString()),
 					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.#", "10"), // Adjust the expected number of roles
 					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.0.id", "1"),
 					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.0.name", "Admin"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.0.permission_count", "3"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.0.user_count", "1"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.0.users.#", "1"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.0.users.0", "admin"),
 					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.1.id", "2"),
 					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.1.name", "Public"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.1.permission_count", "0"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.1.user_count", "0"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.1.users.#", "0"),
 					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.2.id", "3"),
 					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.2.name", "Alpha"),
 					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.3.id", "4"),
@@ -71,3 +85,106 @@ func TestAccRolesDataSource(t *testing.T) {
 const testAccRolesDataSourceConfig = `
 data "superset_roles" "test" {}
 `
+
+func TestAccRolesDataSourceWithoutRoleSearch(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Superset before 5.0 has no role search, and its role list only returns the id and name of roles
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/search/?q=(page_size:5000)",
+		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/?q=(page_size:1)",
+		httpmock.NewStringResponder(200, `{"count": 2, "result": [{"id": 1, "name": "Admin"}]}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+		httpmock.NewStringResponder(200, `{"count": 2, "result": [{"id": 1, "name": "Admin"}, {"id": 2, "name": "Public"}]}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/users/?q=(columns:!(id,username,roles.id),page_size:5000)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "username": "admin", "roles": [{"id": 1}]}, {"id": 2, "username": "viewer", "roles": [{"id": 1}, {"id": 2}]}]}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRolesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.#", "2"),
+					resource.TestCheckNoResourceAttr("data.superset_roles.test", "roles.0.permission_count"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.0.user_count", "2"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.0.users.1", "viewer"),
+					resource.TestCheckNoResourceAttr("data.superset_roles.test", "roles.1.permission_count"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.1.users.0", "viewer"),
+				),
+			},
+		},
+	})
+
+	// The permissions of the roles are not fetched one role at a time
+	if calls := httpmock.GetCallCountInfo()["GET http://superset-host/api/v1/security/roles/1/permissions/"]; calls != 0 {
+		t.Errorf("expected no request per role, got %d", calls)
+	}
+}
+
+func TestAccRolesDataSourceUsersForbidden(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// The provider account may list roles, but not users
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/search/?q=(page_size:5000)",
+		httpmock.NewStringResponder(200, `{"count": 1, "result": [{"id": 1, "name": "Admin", "permission_ids": [1, 2, 3]}]}`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/users/?q=(columns:!(id,username,roles.id),page_size:5000)",
+		httpmock.NewStringResponder(403, `{"message": "Forbidden"}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The roles are still read, with their users left null
+			{
+				Config: providerConfig + testAccRolesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.#", "1"),
+					resource.TestCheckResourceAttr("data.superset_roles.test", "roles.0.permission_count", "3"),
+					resource.TestCheckNoResourceAttr("data.superset_roles.test", "roles.0.user_count"),
+					resource.TestCheckNoResourceAttr("data.superset_roles.test", "roles.0.users"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRolesDataSourceSecurityAPIDisabled(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Without FAB_ADD_SECURITY_API, neither the roles nor the probe endpoint are registered
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/search/?q=(page_size:5000)",
+		httpmock.NewStringResponder(404, `<!doctype html><title>404 Not Found</title>`))
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/?q=(page_size:1)",
+		httpmock.NewStringResponder(404, `<!doctype html><title>404 Not Found</title>`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + testAccRolesDataSourceConfig,
+				ExpectError: regexp.MustCompile(`FAB_ADD_SECURITY_API = True`),
+			},
+		},
+	})
+}
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &securityPermissionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &securityPermissionsDataSource{}
+)
+
+// NewSecurityPermissionsDataSource is a helper function to simplify the provider implementation.
+func NewSecurityPermissionsDataSource() datasource.DataSource {
+	return &securityPermissionsDataSource{}
+}
+
+// securityPermissionsDataSource is the data source implementation.
+type securityPermissionsDataSource struct {
+	client *client.Client
+}
+
+// securityPermissionsDataSourceModel maps the data source schema data.
+type securityPermissionsDataSourceModel struct {
+	ID                types.String              `tfsdk:"id"`
+	MenuAccess        []securityPermissionModel `tfsdk:"menu_access"`
+	DatabaseAccess    []securityPermissionModel `tfsdk:"database_access"`
+	SchemaAccess      []securityPermissionModel `tfsdk:"schema_access"`
+	DatasourceAccess  []securityPermissionModel `tfsdk:"datasource_access"`
+	Actions           []securityPermissionModel `tfsdk:"actions"`
+	Other             []securityPermissionModel `tfsdk:"other"`
+	ActionsByViewMenu map[string][]types.String `tfsdk:"actions_by_view_menu"`
+}
+
+// securityPermissionModel maps a permission, shaped like a superset_role_permissions resource_permissions entry.
+type securityPermissionModel struct {
+	ID         types.Int64  `tfsdk:"id"`
+	Permission types.String `tfsdk:"permission"`
+	ViewMenu   types.String `tfsdk:"view_menu"`
+}
+
+// Metadata returns the data source type name.
+func (d *securityPermissionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_security_permissions"
+}
+
+// Schema defines the schema for the data source.
+func (d *securityPermissionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	permissionList := func(description string) schema.ListNestedAttribute {
+		return schema.ListNestedAttribute{
+			MarkdownDescription: description + " Sorted by view menu, then permission.",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Int64Attribute{
+						MarkdownDescription: "The unique identifier of the permission.",
+						Computed:            true,
+					},
+					"permission": schema.StringAttribute{
+						MarkdownDescription: "The name of the permission.",
+						Computed:            true,
+					},
+					"view_menu": schema.StringAttribute{
+						MarkdownDescription: "The name of the view menu associated with the permission.",
+						Computed:            true,
+					},
+				},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches every permission defined in Superset, grouped by kind, " +
+			"to help building least-privilege roles without parsing the flat permissions list.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the data source, derived from the Superset host and the IDs of the permissions, so it only changes when they do.",
+				Computed:            true,
+			},
+			"menu_access":       permissionList("menu_access permissions, which show entries of the Superset menu."),
+			"database_access":   permissionList("database_access permissions, one per database."),
+			"schema_access":     permissionList("schema_access permissions, one per database schema."),
+			"datasource_access": permissionList("datasource_access permissions, one per dataset."),
+			"actions":           permissionList("can_* permissions, which allow actions on a view or API."),
+			"other":             permissionList("Permissions of any other kind, e.g. all_datasource_access."),
+			"actions_by_view_menu": schema.MapAttribute{
+				MarkdownDescription: "Names of the can_* permissions available on each view menu, keyed by view menu.",
+				Computed:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *securityPermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	permissions, err := d.client.FetchPermissions()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Superset Permissions",
+			err.Error(),
+		)
+		return
+	}
+
+	sort.Slice(permissions, func(i, j int) bool {
+		if permissions[i].ViewMenuName != permissions[j].ViewMenuName {
+			return permissions[i].ViewMenuName < permissions[j].ViewMenuName
+		}
+		return permissions[i].PermissionName < permissions[j].PermissionName
+	})
+
+	var ids []int64
+	for _, perm := range permissions {
+		ids = append(ids, perm.ID)
+	}
+
+	state := securityPermissionsDataSourceModel{
+		ID:                listDataSourceID(d.client.Host, "security_permissions", ids),
+		MenuAccess:        []securityPermissionModel{},
+		DatabaseAccess:    []securityPermissionModel{},
+		SchemaAccess:      []securityPermissionModel{},
+		DatasourceAccess:  []securityPermissionModel{},
+		Actions:           []securityPermissionModel{},
+		Other:             []securityPermissionModel{},
+		ActionsByViewMenu: map[string][]types.String{},
+	}
+
+	for _, perm := range permissions {
+		model := securityPermissionModel{
+			ID:         types.Int64Value(perm.ID),
+			Permission: types.StringValue(perm.PermissionName),
+			ViewMenu:   types.StringValue(perm.ViewMenuName),
+		}
+
+		switch {
+		case perm.PermissionName == "menu_access":
+			state.MenuAccess = append(state.MenuAccess, model)
+		case perm.PermissionName == "database_access":
+			state.DatabaseAccess = append(state.DatabaseAccess, model)
+		case perm.PermissionName == "schema_access":
+			state.SchemaAccess = append(state.SchemaAccess, model)
+		case perm.PermissionName == "datasource_access":
+			state.DatasourceAccess = append(state.DatasourceAccess, model)
+		case strings.HasPrefix(perm.PermissionName, "can_"):
+			state.Actions = append(state.Actions, model)
+			state.ActionsByViewMenu[perm.ViewMenuName] = append(state.ActionsByViewMenu[perm.ViewMenuName], types.StringValue(perm.PermissionName))
+		default:
+			state.Other = append(state.Other, model)
+		}
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *securityPermissionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
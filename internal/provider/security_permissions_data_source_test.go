@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccSecurityPermissionsDataSource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for fetching permissions resources
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/permissions-resources?q=(page:0,page_size:5000)",
+		httpmock.NewStringResponder(200, `{
+			"count": 6,
+			"result": [
+				{"id": 11, "permission": {"name": "can_write"}, "view_menu": {"name": "Dashboard"}},
+				{"id": 10, "permission": {"name": "can_read"}, "view_menu": {"name": "Dashboard"}},
+				{"id": 20, "permission": {"name": "menu_access"}, "view_menu": {"name": "SQL Lab"}},
+				{"id": 30, "permission": {"name": "database_access"}, "view_menu": {"name": "[Trino].(id:34)"}},
+				{"id": 40, "permission": {"name": "datasource_access"}, "view_menu": {"name": "[Trino].[orders](id:7)"}},
+				{"id": 50, "permission": {"name": "all_datasource_access"}, "view_menu": {"name": "all_datasource_access"}}
+			]
+		}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccSecurityPermissionsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_security_permissions.test", "menu_access.#", "1"),
+					resource.TestCheckResourceAttr("data.superset_security_permissions.test", "menu_access.0.view_menu", "SQL Lab"),
+					resource.TestCheckResourceAttr("data.superset_security_permissions.test", "database_access.0.id", "30"),
+					resource.TestCheckResourceAttr("data.superset_security_permissions.test", "schema_access.#", "0"),
+					resource.TestCheckResourceAttr("data.superset_security_permissions.test", "datasource_access.0.id", "40"),
+					resource.TestCheckResourceAttr("data.superset_security_permissions.test", "actions.#", "2"),
+					resource.TestCheckResourceAttr("data.superset_security_permissions.test", "actions.0.permission", "can_read"),
+					resource.TestCheckResourceAttr("data.superset_security_permissions.test", "other.0.permission", "all_datasource_access"),
+					resource.TestCheckResourceAttr("data.superset_security_permissions.test", "actions_by_view_menu.Dashboard.#", "2"),
+					resource.TestCheckResourceAttr("data.superset_security_permissions.test", "actions_by_view_menu.Dashboard.1", "can_write"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSecurityPermissionsDataSourceConfig = `
+data "superset_security_permissions" "test" {}
+`
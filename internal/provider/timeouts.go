@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultOperationTimeout bounds an operation whose timeout is not configured.
+const defaultOperationTimeout = 20 * time.Minute
+
+// timeoutsModel maps the timeouts block of resources whose operations can take minutes.
+type timeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsBlock returns the schema of the timeouts block.
+func timeoutsBlock() schema.SingleNestedBlock {
+	attribute := func(operation string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Maximum duration of the %s operation, as a Go duration string (e.g. \"30s\", \"5m\"). Defaults to %s.", operation, defaultOperationTimeout),
+			Optional:            true,
+			Validators:          []validator.String{durationValidator{}},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Timeouts of the resource operations.",
+		Attributes: map[string]schema.Attribute{
+			"create": attribute("create"),
+			"read":   attribute("read"),
+			"update": attribute("update"),
+			"delete": attribute("delete"),
+		},
+	}
+}
+
+// operationTimeout returns the configured timeout of the given operation, or the default one.
+func operationTimeout(timeouts *timeoutsModel, operation string) (time.Duration, error) {
+	if timeouts == nil {
+		return defaultOperationTimeout, nil
+	}
+
+	var value types.String
+	switch operation {
+	case "create":
+		value = timeouts.Create
+	case "read":
+		value = timeouts.Read
+	case "update":
+		value = timeouts.Update
+	case "delete":
+		value = timeouts.Delete
+	default:
+		return 0, fmt.Errorf("unknown operation %q", operation)
+	}
+
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return defaultOperationTimeout, nil
+	}
+
+	timeout, err := parseTimeout(value.ValueString())
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s timeout: %w", operation, err)
+	}
+	return timeout, nil
+}
+
+// parseTimeout parses a timeout, which must be a positive Go duration string.
+func parseTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a Go duration string: %w", value, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("%q must be positive", value)
+	}
+	return timeout, nil
+}
+
+// withOperationTimeout derives a context bounded by the timeout of the given operation. An invalid timeout
+// is returned as an error along with a context bounded by the default timeout, so reads and deletes of
+// a state stored before timeouts were validated can still proceed.
+func withOperationTimeout(ctx context.Context, timeouts *timeoutsModel, operation string) (context.Context, context.CancelFunc, error) {
+	timeout, err := operationTimeout(timeouts, operation)
+	if err != nil {
+		timeout = defaultOperationTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, err
+}
+
+// addStoredTimeoutWarning warns that the timeout stored in the state of the resource is invalid and the
+// default one is used instead, until the timeouts block is fixed and applied.
+func addStoredTimeoutWarning(diags *diag.Diagnostics, err error) {
+	diags.AddWarning(
+		"Invalid Timeout",
+		fmt.Sprintf("%s. The default of %s is used instead until the timeouts block is fixed and applied.", err, defaultOperationTimeout),
+	)
+}
+
+// durationValidator rejects timeouts that are not positive Go duration strings at validate time, so an
+// invalid timeout never reaches the state.
+type durationValidator struct{}
+
+// Description describes the validation in plain text formatting.
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive Go duration string"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() == "" {
+		return
+	}
+
+	if _, err := parseTimeout(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Timeout", fmt.Sprintf("The timeout %s, e.g. \"30s\" or \"5m\".", err))
+	}
+}
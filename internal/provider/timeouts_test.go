@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOperationTimeout(t *testing.T) {
+	timeouts := &timeoutsModel{
+		Create: types.StringValue("5m"),
+		Read:   types.StringNull(),
+		Update: types.StringValue("soon"),
+		Delete: types.StringValue("-1s"),
+	}
+
+	cases := []struct {
+		operation string
+		timeouts  *timeoutsModel
+		expected  time.Duration
+		wantErr   bool
+	}{
+		{operation: "create", timeouts: timeouts, expected: 5 * time.Minute},
+		{operation: "read", timeouts: timeouts, expected: defaultOperationTimeout},
+		{operation: "update", timeouts: timeouts, wantErr: true},
+		{operation: "delete", timeouts: timeouts, wantErr: true},
+		{operation: "create", timeouts: nil, expected: defaultOperationTimeout},
+	}
+
+	for _, c := range cases {
+		timeout, err := operationTimeout(c.timeouts, c.operation)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, got timeout %s", c.operation, timeout)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.operation, err)
+			continue
+		}
+		if timeout != c.expected {
+			t.Errorf("%s: expected timeout %s, got %s", c.operation, c.expected, timeout)
+		}
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	cases := map[string]bool{
+		"30s": false,
+		"5m":  false,
+		"1x":  true,
+		"0s":  true,
+		"-1m": true,
+	}
+
+	for value, wantErr := range cases {
+		resp := &validator.StringResponse{}
+		durationValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("timeouts").AtName("read"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+		if resp.Diagnostics.HasError() != wantErr {
+			t.Errorf("%s: expected an error %t, got %v", value, wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestWithOperationTimeoutInvalid(t *testing.T) {
+	// A timeout stored before it was validated falls back to the default, so reads can proceed
+	ctx, cancel, err := withOperationTimeout(context.Background(), &timeoutsModel{Read: types.StringValue("1x")}, "read")
+	defer cancel()
+	if err == nil {
+		t.Error("expected the invalid timeout to be reported")
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		t.Error("expected the context to be bounded by the default timeout")
+	}
+}
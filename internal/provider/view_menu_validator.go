@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// viewMenuFormats describes the view menu forms Superset uses, for diagnostics.
+const viewMenuFormats = "Expected a plain view menu name (e.g. \"Dashboard\", \"SQL Lab\", \"all_database_access\"), " +
+	"or a bracketed object name such as \"[Database].(id:N)\" for database_access, \"[Database].[schema]\" for schema_access " +
+	"or \"[Database].[schema].[table](id:N)\" for datasource_access."
+
+var (
+	// idSuffixViewMenuPattern matches what may follow the bracketed names: an optional "(id:N)" suffix.
+	idSuffixViewMenuPattern = regexp.MustCompile(`^(\.?\(id:\d+\))?$`)
+
+	// idSuffixPattern matches anything that looks like an attempt at an "(id:N)" suffix.
+	idSuffixPattern = regexp.MustCompile(`\(\s*id\b`)
+)
+
+// viewMenuValidator rejects view menu strings Superset can never match, with an explanation of
+// the expected formats, instead of letting the apply fail with an opaque "permission not found".
+// Superset object names may themselves contain brackets, so a view menu whose brackets cannot be
+// parsed only gets a warning.
+type viewMenuValidator struct{}
+
+// Description describes the validation in plain text formatting.
+func (v viewMenuValidator) Description(_ context.Context) string {
+	return "value must be a well-formed Superset view menu name"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v viewMenuValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v viewMenuValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	problem, certain := viewMenuProblem(req.ConfigValue.ValueString())
+	switch {
+	case problem == "":
+	case certain:
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid View Menu Format",
+			fmt.Sprintf("The view menu %q %s. %s", req.ConfigValue.ValueString(), problem, viewMenuFormats),
+		)
+	default:
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Unusual View Menu Format",
+			fmt.Sprintf("The view menu %q %s. %s It is used as is, since object names may contain brackets.", req.ConfigValue.ValueString(), problem, viewMenuFormats),
+		)
+	}
+}
+
+// viewMenuProblem returns why the view menu is malformed, or an empty string if it is well-formed.
+// certain is false when the problem may come from brackets inside an object name.
+func viewMenuProblem(viewMenu string) (problem string, certain bool) {
+	switch {
+	case viewMenu == "":
+		return "is empty", true
+	case strings.TrimSpace(viewMenu) != viewMenu:
+		return "has leading or trailing whitespace", true
+	}
+
+	bracketed := strings.ContainsAny(viewMenu, "[]")
+	if !bracketed {
+		if idSuffixPattern.MatchString(viewMenu) {
+			return "has an (id:N) suffix but no bracketed database name", true
+		}
+		return "", false
+	}
+
+	if !strings.HasPrefix(viewMenu, "[") {
+		return "has brackets but does not start with one", false
+	}
+	names, rest, ok := splitBracketedNames(viewMenu)
+	if !ok || strings.ContainsAny(rest, "[]") {
+		return "is not a sequence of bracketed names joined by dots", false
+	}
+	if !idSuffixViewMenuPattern.MatchString(rest) {
+		if idSuffixPattern.MatchString(rest) {
+			return "has a malformed id suffix, which must be written exactly as (id:N) with no spaces", true
+		}
+		return "is not a sequence of bracketed names joined by dots", false
+	}
+	for _, name := range names {
+		if strings.TrimSpace(name) != name {
+			return "has whitespace just inside a bracket", true
+		}
+	}
+	return "", false
+}
+
+// splitBracketedNames splits "[a].[b]..." into its names, allowing balanced brackets inside a name as in
+// "[Sales [EU]]", and returns what follows the last name. ok is false when the brackets do not close.
+func splitBracketedNames(viewMenu string) (names []string, rest string, ok bool) {
+	rest = viewMenu
+	for {
+		depth := 0
+		end := -1
+		for i, r := range rest {
+			switch r {
+			case '[':
+				depth++
+			case ']':
+				depth--
+			}
+			if depth == 0 {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			return nil, "", false
+		}
+		names = append(names, rest[1:end])
+		rest = rest[end+1:]
+		if !strings.HasPrefix(rest, ".[") {
+			return names, rest, true
+		}
+		rest = rest[1:]
+	}
+}
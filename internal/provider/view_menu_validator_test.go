@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestViewMenuProblem(t *testing.T) {
+	valid := []string{
+		"Dashboard",
+		"SQL Lab",
+		"all_database_access",
+		"[Trino].(id:34)",
+		"[Trino].[devoriginationzestorage]",
+		"[Trino].[hive].[sales]",
+		"[Trino].[sales].[orders](id:12)",
+		"[Sales [EU]].(id:3)",
+		"[Sales [EU]].[public].[orders [2024]](id:12)",
+	}
+	for _, viewMenu := range valid {
+		if problem, _ := viewMenuProblem(viewMenu); problem != "" {
+			t.Errorf("%q: expected no problem, got %q", viewMenu, problem)
+		}
+	}
+
+	// Problems that may come from brackets inside object names are only warned about
+	invalid := map[string]bool{
+		"":                   true,
+		" Dashboard":         true,
+		"[Trino].[sales] ":   true,
+		"Trino].[sales]":     false,
+		"[Trino.[sales]":     false,
+		"[Trino].(id: 34)":   true,
+		"[Trino].(id=34)":    true,
+		"[Trino].[ sales ]":  true,
+		"[Trino] [sales]":    false,
+		"[Sales ]EU].(id:3)": false,
+		"Trino(id:34)":       true,
+	}
+	for viewMenu, expected := range invalid {
+		problem, certain := viewMenuProblem(viewMenu)
+		if problem == "" {
+			t.Errorf("%q: expected a problem, got none", viewMenu)
+		} else if certain != expected {
+			t.Errorf("%q: expected certain %t, got %t for %q", viewMenu, expected, certain, problem)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &viewMenusDataSource{}
+	_ datasource.DataSourceWithConfigure = &viewMenusDataSource{}
+)
+
+// NewViewMenusDataSource is a helper function to simplify the provider implementation.
+func NewViewMenusDataSource() datasource.DataSource {
+	return &viewMenusDataSource{}
+}
+
+// viewMenusDataSource is the data source implementation.
+type viewMenusDataSource struct {
+	client *client.Client
+}
+
+// viewMenusDataSourceModel maps the data source schema data.
+type viewMenusDataSourceModel struct {
+	ID        types.String    `tfsdk:"id"`
+	ViewMenus []viewMenuModel `tfsdk:"view_menus"`
+}
+
+// viewMenuModel maps the view menu schema data.
+type viewMenuModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+// Metadata returns the data source type name.
+func (d *viewMenusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_view_menus"
+}
+
+// Schema defines the schema for the data source.
+func (d *viewMenusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the list of view menus (e.g. Dashboard, SQL Lab, Datasource) that permissions can be granted on.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the data source, derived from the Superset host and the IDs of the listed view menus, so it only changes when they do.",
+				Computed:            true,
+			},
+			"view_menus": schema.ListNestedAttribute{
+				MarkdownDescription: "List of view menus.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Numeric identifier of the view menu.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the view menu.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *viewMenusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state viewMenusDataSourceModel
+
+	viewMenus, count, err := d.client.FetchViewMenus()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Superset View Menus",
+			err.Error(),
+		)
+		return
+	}
+	warnIfTruncated(&resp.Diagnostics, "view menus", len(viewMenus), count)
+
+	for _, viewMenu := range viewMenus {
+		state.ViewMenus = append(state.ViewMenus, viewMenuModel{
+			ID:   types.Int64Value(viewMenu.ID),
+			Name: types.StringValue(viewMenu.Name),
+		})
+	}
+
+	var ids []int64
+	for _, viewMenu := range viewMenus {
+		ids = append(ids, viewMenu.ID)
+	}
+	state.ID = listDataSourceID(d.client.Host, "view_menus", ids)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *viewMenusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAccViewMenusDataSource(t *testing.T) {
+	// Activate httpmock
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// Mock the Superset API login response
+	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
+
+	// Mock the Superset API response for fetching view menus
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/view-menus/?q=(page_size:5000)",
+		httpmock.NewStringResponder(200, `{
+			"result": [
+				{"id": 1, "name": "Dashboard"},
+				{"id": 2, "name": "SQL Lab"},
+				{"id": 3, "name": "Datasource"}
+			]
+		}`))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccViewMenusDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_view_menus.test", "view_menus.#", "3"),
+					resource.TestCheckResourceAttr("data.superset_view_menus.test", "view_menus.0.id", "1"),
+					resource.TestCheckResourceAttr("data.superset_view_menus.test", "view_menus.0.name", "Dashboard"),
+					resource.TestCheckResourceAttr("data.superset_view_menus.test", "view_menus.1.name", "SQL Lab"),
+					resource.TestCheckResourceAttr("data.superset_view_menus.test", "view_menus.2.name", "Datasource"),
+				),
+			},
+		},
+	})
+}
+
+const testAccViewMenusDataSourceConfig = `
+data "superset_view_menus" "test" {}
+`